@@ -0,0 +1,144 @@
+package trackerr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Attr is a key value pair that carries contextual information, such as a
+// user ID or file path, so it's not lost within error messages.
+//
+// Attrs passed as arguments to Untracked, Because, or BecauseOf are separated
+// from the formatting arguments and attached to the resultant error.
+//
+//		e := ErrLoadingData.Because("File '%s' not found", path, trackerr.KV("path", path))
+//
+// Sensitive attributes, such as those holding personal data, are never
+// exposed to users, e.g. via WriteProblem, nor written by FormatAttrs. They're
+// still available via Attrs, CollectAttrs, and StackValue.
+type Attr struct {
+	Key       string
+	Value     any
+	Sensitive bool
+}
+
+// KV returns a new Attr.
+func KV(key string, value any) Attr {
+	return Attr{
+		Key:   key,
+		Value: value,
+	}
+}
+
+// Sensitive returns a new Attr marked as sensitive.
+//
+//		e := ErrLogin.Because("Wrong password", trackerr.Sensitive("email", email))
+func Sensitive(key string, value any) Attr {
+	return Attr{
+		Key:       key,
+		Value:     value,
+		Sensitive: true,
+	}
+}
+
+// String returns the Attr in the form 'key=value'.
+func (a Attr) String() string {
+	return fmt.Sprintf("%s=%v", a.Key, a.Value)
+}
+
+// CollectAttrs returns the attributes of every error in the stack.
+//
+// Attributes are ordered by the depth of the error they belong to, head first,
+// then by the order in which they were attached. Where two errors have an
+// attribute with the same key, the shallower one wins and the deeper one is
+// omitted. That is, context added while the error propagates up the stack
+// overrides that added closer to the root cause.
+//
+//		head := ErrLoadingData.WithAttrs(trackerr.KV("user", 2))
+//		cause := trackerr.Untracked("Not found", trackerr.KV("user", 1), trackerr.KV("file", "data.csv"))
+//
+//		attrs := trackerr.CollectAttrs(head.CausedBy(cause))
+//
+//		// attrs: [
+//		// 	user=2,
+//		// 	file=data.csv,
+//		// ]
+func CollectAttrs(e error) []Attr {
+	var attrs []Attr
+	seen := map[string]bool{}
+
+	for _, err := range SliceStack(e) {
+		for _, a := range ownAttrs(err) {
+			if !seen[a.Key] {
+				seen[a.Key] = true
+				attrs = append(attrs, a)
+			}
+		}
+	}
+
+	return attrs
+}
+
+// FormatAttrs returns an ErrorFormatter that suffixes the message of each
+// error that has attributes with those attributes before passing it on to f.
+// Sensitive attributes are left out.
+//
+//		s := trackerr.ErrorStackf(e, trackerr.FormatAttrs(trackerr.DefaultFormatter))
+//
+//		// Failed to load data {user=2}
+//		// ⤷ Not found {user=1, file=data.csv}
+func FormatAttrs(f ErrorFormatter) ErrorFormatter {
+	return func(errMsg string, e error, isFirst bool) string {
+		if attrs := insensitive(ownAttrs(e)); len(attrs) > 0 {
+			errMsg += " " + fmtAttrs(attrs)
+		}
+
+		return applyFormatter(f, errMsg, e, isFirst)
+	}
+}
+
+func ownAttrs(e error) []Attr {
+	if a, ok := e.(interface{ Attrs() []Attr }); ok {
+		return a.Attrs()
+	}
+	return nil
+}
+
+func insensitive(attrs []Attr) []Attr {
+	var result []Attr
+
+	for _, a := range attrs {
+		if !a.Sensitive {
+			result = append(result, a)
+		}
+	}
+
+	return result
+}
+
+func fmtAttrs(attrs []Attr) string {
+	sb := strings.Builder{}
+	sb.WriteRune('{')
+
+	for i, a := range attrs {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(a.String())
+	}
+
+	sb.WriteRune('}')
+	return sb.String()
+}
+
+// appendAttrs appends without modifying the backing array of attrs which may
+// be shared with other copies of an error.
+func appendAttrs(attrs []Attr, more []Attr) []Attr {
+	if len(more) == 0 {
+		return attrs
+	}
+
+	result := make([]Attr, 0, len(attrs)+len(more))
+	result = append(result, attrs...)
+	return append(result, more...)
+}
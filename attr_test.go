@@ -0,0 +1,91 @@
+package trackerr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Untracked_Attrs_1(t *testing.T) {
+	act := Untracked("abc%d%d", 1, KV("x", 1), 2, KV("y", "z"))
+
+	require.Equal(t, "abc12", act.Error())
+	require.Equal(t, []Attr{KV("x", 1), KV("y", "z")}, act.Attrs())
+}
+
+func Test_TrackedError_Attrs_1(t *testing.T) {
+	r := IntRealm{}
+	abc := r.New("abc")
+
+	e := abc.Because("efg%d", 1, KV("x", 1))
+
+	require.Nil(t, e.(*TrackedError).Attrs())
+	require.Equal(t, "efg1", Unwrap(e).Error())
+	require.Equal(t, []Attr{KV("x", 1)}, Unwrap(e).(*UntrackedError).Attrs())
+
+	e = abc.BecauseOf(Untracked("hij"), "efg", KV("y", 2))
+	require.Equal(t, []Attr{KV("y", 2)}, Unwrap(e).(*UntrackedError).Attrs())
+}
+
+func Test_WithAttrs_1(t *testing.T) {
+	r := IntRealm{}
+	abc := r.New("abc")
+
+	a := abc.WithAttrs(KV("x", 1))
+	b := a.WithAttrs(KV("y", 2))
+	c := a.WithAttrs(KV("z", 3))
+
+	require.Nil(t, abc.Attrs())
+	require.Equal(t, []Attr{KV("x", 1)}, a.Attrs())
+	require.Equal(t, []Attr{KV("x", 1), KV("y", 2)}, b.Attrs())
+	require.Equal(t, []Attr{KV("x", 1), KV("z", 3)}, c.Attrs())
+	require.True(t, b.Is(abc))
+
+	e := a.CausedBy(Untracked("efg"))
+	require.Equal(t, []Attr{KV("x", 1)}, e.(*TrackedError).Attrs())
+
+	u := Untracked("efg").WithAttrs(KV("x", 1))
+	require.Equal(t, []Attr{KV("x", 1)}, u.Attrs())
+}
+
+func Test_CollectAttrs_1(t *testing.T) {
+	r := IntRealm{}
+
+	head := r.New("abc").WithAttrs(KV("user", 2))
+	mid := Untracked("efg", KV("user", 1), KV("file", "data.csv"))
+	root := Untracked("hij", KV("retry", 3), KV("file", "other.csv"))
+
+	e := head.CausedBy(mid.CausedBy(root))
+	act := CollectAttrs(e)
+
+	exp := []Attr{
+		KV("user", 2),
+		KV("file", "data.csv"),
+		KV("retry", 3),
+	}
+
+	require.Equal(t, exp, act)
+	require.Nil(t, CollectAttrs(Untracked("abc")))
+}
+
+func Test_FormatAttrs_1(t *testing.T) {
+	r := IntRealm{}
+
+	head := r.New("abc").WithAttrs(KV("user", 2))
+	mid := Untracked("efg")
+	root := Untracked("hij", KV("retry", 3), KV("file", "data.csv"))
+
+	e := head.CausedBy(mid.CausedBy(root))
+	act := ErrorStackf(e, FormatAttrs(DefaultFormatter))
+
+	expLines := []string{
+		"abc {user=2}",
+		"⤷ efg",
+		"⤷ hij {retry=3, file=data.csv}",
+		"",
+	}
+	exp := strings.Join(expLines, "\n")
+
+	require.Equal(t, exp, act)
+}
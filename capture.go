@@ -0,0 +1,194 @@
+package trackerr
+
+import (
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// CaptureMode determines what, if any, call stack information is recorded
+// when errors are created or wrapped.
+type CaptureMode int32
+
+const (
+	// CaptureDefault is the zero value. When set on a Realm the global capture
+	// mode is used instead. When set globally it is the same as CaptureNone.
+	CaptureDefault CaptureMode = iota
+
+	// CaptureNone records nothing. Errors have no call site information but
+	// creating and wrapping them is as cheap as possible.
+	CaptureNone
+
+	// CaptureCaller records the program counter of the function that created
+	// or wrapped an error.
+	CaptureCaller
+
+	// CaptureStack records the program counters of the full call stack, up to
+	// a depth of 32, at the point an error was created or wrapped. Only the
+	// raw program counters are recorded, they are not resolved into Frames
+	// until requested, e.g. by StackTrace or FormatStackTrace.
+	CaptureStack
+)
+
+const maxStackDepth = 32
+
+var globalCapture int32
+
+// SetCapture sets the global CaptureMode used by Realms that have not set
+// their own and by untracked errors.
+//
+// Capturing is off by default.
+func SetCapture(m CaptureMode) {
+	atomic.StoreInt32(&globalCapture, int32(m))
+}
+
+// SetCapture sets the CaptureMode used when tracked errors belonging to the
+// Realm are wrapped. Setting CaptureDefault reverts to the global mode.
+func (r *IntRealm) SetCapture(m CaptureMode) {
+	atomic.StoreInt32(&r.capture, int32(m))
+}
+
+// Frame represents a single function call within a call stack.
+type Frame struct {
+	Function string
+	File     string
+	Line     int
+}
+
+// String returns the frame in the form 'pkg.Function file.go:42' where only
+// the last element of the package path and file path are used.
+func (f Frame) String() string {
+	fn := f.Function
+	if i := strings.LastIndex(fn, "/"); i >= 0 {
+		fn = fn[i+1:]
+	}
+
+	return fn + " " + filepath.Base(f.File) + ":" + strconv.Itoa(f.Line)
+}
+
+func captureMode(realm Realm) CaptureMode {
+	if r, ok := realm.(*IntRealm); ok && r != nil {
+		if m := CaptureMode(atomic.LoadInt32(&r.capture)); m != CaptureDefault {
+			return m
+		}
+	}
+
+	return CaptureMode(atomic.LoadInt32(&globalCapture))
+}
+
+// capture returns the program counters to record for an error belonging to
+// the realm. Skip is the number of frames above the function calling capture
+// at which the error was created or wrapped.
+func capture(realm Realm, skip int) []uintptr {
+	var pcs []uintptr
+
+	switch captureMode(realm) {
+	case CaptureCaller:
+		pcs = make([]uintptr, 1)
+	case CaptureStack:
+		pcs = make([]uintptr, maxStackDepth)
+	default:
+		return nil
+	}
+
+	n := runtime.Callers(skip+2, pcs)
+	return pcs[:n]
+}
+
+// recapture replaces the recorded program counters of errors created within
+// this package, on behalf of the caller, with ones for the caller.
+func recapture(e error, skip int) {
+	switch v := e.(type) {
+	case *TrackedError:
+		v.pcs = capture(v.realm, skip+1)
+	case *UntrackedError:
+		v.pcs = capture(nil, skip+1)
+	}
+}
+
+func callSite(pcs []uintptr) (Frame, bool) {
+	if len(pcs) == 0 {
+		return Frame{}, false
+	}
+
+	f, _ := runtime.CallersFrames(pcs[:1]).Next()
+	return toFrame(f), true
+}
+
+func stackTrace(pcs []uintptr) []Frame {
+	if len(pcs) == 0 {
+		return nil
+	}
+
+	var frames []Frame
+	rf := runtime.CallersFrames(pcs)
+
+	for {
+		f, more := rf.Next()
+		frames = append(frames, toFrame(f))
+
+		if !more {
+			return frames
+		}
+	}
+}
+
+func toFrame(f runtime.Frame) Frame {
+	return Frame{
+		Function: f.Function,
+		File:     f.File,
+		Line:     f.Line,
+	}
+}
+
+// FormatCallSite returns an ErrorFormatter that suffixes the message of each
+// error, that has a recorded call site, with that call site before passing it
+// on to f.
+//
+//		trackerr.SetCapture(trackerr.CaptureCaller)
+//		s := trackerr.ErrorStackf(e, trackerr.FormatCallSite(trackerr.DefaultFormatter))
+//
+//		// Workflow error @ main.run main.go:24
+//		// ⤷ Failed to read data @ data.Load load.go:12
+func FormatCallSite(f ErrorFormatter) ErrorFormatter {
+	return func(errMsg string, e error, isFirst bool) string {
+		if cs, ok := e.(interface{ CallSite() (Frame, bool) }); ok {
+			if fr, ok := cs.CallSite(); ok {
+				errMsg += " @ " + fr.String()
+			}
+		}
+
+		return applyFormatter(f, errMsg, e, isFirst)
+	}
+}
+
+// FormatStackTrace returns an ErrorFormatter that appends the recorded stack
+// trace of each error, one indented frame per line, to its message before
+// passing it on to f.
+//
+//		trackerr.SetCapture(trackerr.CaptureStack)
+//		s := trackerr.ErrorStackf(e, trackerr.FormatStackTrace(trackerr.DefaultFormatter))
+//
+//		// Workflow error
+//		//     at main.run main.go:24
+//		//     at main.main main.go:12
+//		// ⤷ Failed to read data
+func FormatStackTrace(f ErrorFormatter) ErrorFormatter {
+	return func(errMsg string, e error, isFirst bool) string {
+		if st, ok := e.(interface{ StackTrace() []Frame }); ok {
+			sb := strings.Builder{}
+			sb.WriteString(errMsg)
+
+			for _, fr := range st.StackTrace() {
+				sb.WriteString("\n    at ")
+				sb.WriteString(fr.String())
+			}
+
+			errMsg = sb.String()
+		}
+
+		return applyFormatter(f, errMsg, e, isFirst)
+	}
+}
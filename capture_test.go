@@ -0,0 +1,192 @@
+package trackerr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func withCapture(m CaptureMode) func() {
+	SetCapture(m)
+	return func() {
+		SetCapture(CaptureDefault)
+	}
+}
+
+func requireCallSite(t *testing.T, e error, fn string) {
+	t.Helper()
+
+	cs, ok := e.(interface{ CallSite() (Frame, bool) })
+	require.True(t, ok)
+
+	f, ok := cs.CallSite()
+	require.True(t, ok)
+	require.Equal(t, "github.com/PaulioRandall/go-trackerr."+fn, f.Function)
+	require.True(t, strings.HasSuffix(f.File, "_test.go"))
+}
+
+func Test_CallSite_1(t *testing.T) {
+	r := IntRealm{}
+	abc := r.New("abc")
+
+	_, ok := abc.Because("efg").(*TrackedError).CallSite()
+	require.False(t, ok)
+
+	_, ok = Untracked("efg").CallSite()
+	require.False(t, ok)
+}
+
+func Test_CallSite_2(t *testing.T) {
+	defer withCapture(CaptureCaller)()
+
+	r := IntRealm{}
+	abc := r.New("abc")
+	efg := Untracked("efg")
+
+	requireCallSite(t, abc.Because("hij"), "Test_CallSite_2")
+	requireCallSite(t, abc.BecauseOf(efg, "hij"), "Test_CallSite_2")
+	requireCallSite(t, abc.CausedBy(efg), "Test_CallSite_2")
+	requireCallSite(t, efg, "Test_CallSite_2")
+	requireCallSite(t, efg.Because("hij"), "Test_CallSite_2")
+	requireCallSite(t, efg.BecauseOf(efg, "hij"), "Test_CallSite_2")
+	requireCallSite(t, efg.CausedBy(efg), "Test_CallSite_2")
+}
+
+func Test_CallSite_3(t *testing.T) {
+	defer withCapture(CaptureCaller)()
+
+	r := IntRealm{}
+	abc := r.New("abc")
+	efg := r.New("efg")
+	hij := Untracked("hij")
+
+	e := Stack(hij, efg, abc)
+	requireCallSite(t, e, "Test_CallSite_3")
+	requireCallSite(t, Unwrap(e), "Test_CallSite_3")
+
+	e = abc.CausedBy(hij, efg)
+	requireCallSite(t, e, "Test_CallSite_3")
+	requireCallSite(t, Unwrap(e), "Test_CallSite_3")
+}
+
+func Test_CallSite_4(t *testing.T) {
+	r := IntRealm{}
+	r.SetCapture(CaptureCaller)
+
+	abc := r.New("abc")
+	requireCallSite(t, abc.Because("efg"), "Test_CallSite_4")
+
+	_, ok := Untracked("efg").CallSite()
+	require.False(t, ok)
+
+	defer withCapture(CaptureCaller)()
+	r.SetCapture(CaptureNone)
+
+	_, ok = abc.Because("efg").(*TrackedError).CallSite()
+	require.False(t, ok)
+}
+
+func Test_FormatCallSite_1(t *testing.T) {
+	r := IntRealm{}
+	abc := r.New("abc")
+
+	r.SetCapture(CaptureCaller)
+	e := abc.Because("efg")
+
+	act := ErrorStackf(e, FormatCallSite(DefaultFormatter))
+	lines := strings.Split(act, "\n")
+
+	require.Len(t, lines, 3)
+	require.Regexp(t, `^abc @ go-trackerr\.Test_FormatCallSite_1 capture_test\.go:\d+$`, lines[0])
+	require.Equal(t, "⤷ efg", lines[1])
+}
+
+func Test_Frame_1(t *testing.T) {
+	f := Frame{
+		Function: "github.com/user/repo/pkg.(*Type).Method",
+		File:     "/home/user/repo/pkg/file.go",
+		Line:     42,
+	}
+
+	require.Equal(t, "pkg.(*Type).Method file.go:42", f.String())
+}
+
+func Test_StackTrace_1(t *testing.T) {
+	r := IntRealm{}
+	r.SetCapture(CaptureStack)
+
+	e := r.New("abc").Because("efg").(*TrackedError)
+	frames := e.StackTrace()
+
+	require.Greater(t, len(frames), 1)
+	require.Equal(t, "github.com/PaulioRandall/go-trackerr.Test_StackTrace_1", frames[0].Function)
+	require.Equal(t, "testing.tRunner", frames[1].Function)
+
+	f, ok := e.CallSite()
+	require.True(t, ok)
+	require.Equal(t, frames[0], f)
+}
+
+func Test_StackTrace_2(t *testing.T) {
+	r := IntRealm{}
+	r.SetCapture(CaptureCaller)
+
+	e := r.New("abc").Because("efg").(*TrackedError)
+	require.Len(t, e.StackTrace(), 1)
+
+	r.SetCapture(CaptureNone)
+	e = r.New("abc").Because("efg").(*TrackedError)
+	require.Nil(t, e.StackTrace())
+}
+
+func Test_FormatStackTrace_1(t *testing.T) {
+	defer withCapture(CaptureStack)()
+
+	e := Untracked("abc").Because("efg")
+
+	act := ErrorStackf(e, FormatStackTrace(DefaultFormatter))
+	lines := strings.Split(act, "\n")
+
+	require.Equal(t, "abc", lines[0])
+	require.Regexp(t, `^    at go-trackerr\.Test_FormatStackTrace_1 capture_test\.go:\d+$`, lines[1])
+	require.Regexp(t, `^    at testing\.tRunner testing\.go:\d+$`, lines[2])
+}
+
+func benchmarkCapture(b *testing.B, m CaptureMode) {
+	r := IntRealm{}
+	r.SetCapture(m)
+	abc := r.New("abc")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = abc.Because("efg")
+	}
+}
+
+func Benchmark_Capture_None(b *testing.B) {
+	benchmarkCapture(b, CaptureNone)
+}
+
+func Benchmark_Capture_Caller(b *testing.B) {
+	benchmarkCapture(b, CaptureCaller)
+}
+
+func Benchmark_Capture_Stack(b *testing.B) {
+	benchmarkCapture(b, CaptureStack)
+}
+
+func Benchmark_Capture_StackTrace(b *testing.B) {
+	r := IntRealm{}
+	r.SetCapture(CaptureStack)
+	e := r.New("abc").Because("efg").(*TrackedError)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = e.StackTrace()
+	}
+}
@@ -0,0 +1,91 @@
+package trackerr
+
+// Wrap returns a new untracked error, with the message formed from msg and
+// args, that wraps the cause.
+//
+//		e := trackerr.Wrap(cause, "Failed to read '%s'", file)
+//
+// It's the same as calling trackerr.Untracked(msg, args...).CausedBy(cause)
+// except if the cause is nil the new error is still returned.
+func Wrap(cause error, msg string, args ...any) error {
+	e := causedBy(cause, msg, args...)
+	e.pcs = capture(nil, 1)
+	return e
+}
+
+// Checkpoint is the same as Wrap except the new error is marked as a
+// checkpoint.
+//
+// Checkpoints mark significant boundaries within an error stack, such as the
+// edge of an architectural layer or service, so they can be highlighted when
+// printed or searched for programmatically.
+//
+//		func (s *UserService) Get(id int) (User, error) {
+//			u, e := s.repo.Find(id)
+//			if e != nil {
+//				return User{}, trackerr.Checkpoint(e, "User service")
+//			}
+//			return u, nil
+//		}
+func Checkpoint(cause error, msg string, args ...any) error {
+	e := causedBy(cause, msg, args...)
+	e.checkpoint = true
+	e.pcs = capture(nil, 1)
+	return e
+}
+
+// IsCheckpoint returns true if the error, ignoring its causes, was created
+// via Checkpoint.
+func IsCheckpoint(e error) bool {
+	u, ok := e.(*UntrackedError)
+	return ok && u.checkpoint
+}
+
+// FormatCheckpoint returns an ErrorFormatter that highlights the message of
+// each checkpoint before passing it on to f.
+//
+//		s := trackerr.ErrorStackf(e, trackerr.FormatCheckpoint(trackerr.DefaultFormatter))
+//
+//		// Failed to handle request
+//		// ⤷ *** User service ***
+//		// ⤷ Could not find user
+func FormatCheckpoint(f ErrorFormatter) ErrorFormatter {
+	return func(errMsg string, e error, isFirst bool) string {
+		if IsCheckpoint(e) {
+			errMsg = "*** " + errMsg + " ***"
+		}
+
+		return applyFormatter(f, errMsg, e, isFirst)
+	}
+}
+
+// ContextFor wraps the rootCause within a copy of trackedCause then wraps the
+// result within a copy of the receiving error.
+//
+//		e := ErrLoadingData.ContextFor(ErrOpeningDatabase, cause)
+//
+//		```
+//		Failed to load data
+//		⤷ Could not open database
+//		⤷ cause message
+//		```
+//
+// This is shorthand for e.CausedBy(rootCause, trackedCause) except if the
+// rootCause is nil the trackedCause is still attached.
+func (e TrackedError) ContextFor(trackedCause *TrackedError, rootCause error) error {
+	e.pcs = capture(e.realm, 1)
+	e.cause = trackedCause.CausedBy(rootCause)
+	recapture(e.cause, 1)
+	return &e
+}
+
+// ContextFor wraps the rootCause within a copy of trackedCause then wraps the
+// result within a copy of the receiving error.
+//
+// See TrackedError.ContextFor for an example.
+func (e UntrackedError) ContextFor(trackedCause *TrackedError, rootCause error) error {
+	e.pcs = capture(nil, 1)
+	e.cause = trackedCause.CausedBy(rootCause)
+	recapture(e.cause, 1)
+	return &e
+}
@@ -0,0 +1,95 @@
+package trackerr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Wrap_1(t *testing.T) {
+	a := Untracked("a")
+
+	act := Wrap(a, "b%d", 1)
+	exp := &UntrackedError{
+		msg:   "b1",
+		cause: a,
+	}
+
+	require.Equal(t, exp, act)
+	require.False(t, IsCheckpoint(act))
+	require.Equal(t, &UntrackedError{msg: "b"}, Wrap(nil, "b"))
+}
+
+func Test_Checkpoint_1(t *testing.T) {
+	a := Untracked("a")
+
+	act := Checkpoint(a, "b%d", 1)
+	exp := &UntrackedError{
+		msg:        "b1",
+		cause:      a,
+		checkpoint: true,
+	}
+
+	require.Equal(t, exp, act)
+	require.True(t, IsCheckpoint(act))
+	require.False(t, IsCheckpoint(Wrap(act, "c")))
+	require.False(t, IsCheckpoint(New("d")))
+}
+
+func Test_FormatCheckpoint_1(t *testing.T) {
+	alice := Untracked("Alice's message")
+	bob := Checkpoint(alice, "Bob's message")
+	charlie := Wrap(bob, "Charlie's message")
+
+	act := ErrorStackf(charlie, FormatCheckpoint(DefaultFormatter))
+
+	expLines := []string{
+		"Charlie's message",
+		"⤷ *** Bob's message ***",
+		"⤷ Alice's message",
+		"",
+	}
+	exp := strings.Join(expLines, "\n")
+
+	require.Equal(t, exp, act)
+}
+
+func Test_ContextFor_1(t *testing.T) {
+	r := IntRealm{}
+	head := r.New("head")
+	ctx := r.New("context")
+	root := Untracked("root")
+
+	e := head.ContextFor(ctx, root)
+
+	require.True(t, AllOrdered(e, head, ctx, root))
+	require.Equal(t, "head\n⤷ context\n⤷ root\n", ErrorStack(e))
+
+	e = Untracked("head").ContextFor(ctx, root)
+	require.True(t, AllOrdered(e, ctx, root))
+
+	e = head.ContextFor(ctx, nil)
+	require.True(t, AllOrdered(e, head, ctx))
+	require.Nil(t, Unwrap(Unwrap(e)))
+}
+
+func Test_ContextFor_2(t *testing.T) {
+	r := IntRealm{}
+	r.SetCapture(CaptureCaller)
+
+	e := r.New("head").ContextFor(r.New("context"), Untracked("root"))
+
+	requireCallSite(t, e, "Test_ContextFor_2")
+	requireCallSite(t, Unwrap(e), "Test_ContextFor_2")
+}
+
+func Test_UnmarshalStack_Checkpoint_1(t *testing.T) {
+	data, e := MarshalStack(Checkpoint(Untracked("a"), "b"))
+	require.Nil(t, e)
+
+	act, e := UnmarshalStack(data)
+	require.Nil(t, e)
+	require.True(t, IsCheckpoint(act))
+	require.False(t, IsCheckpoint(Unwrap(act)))
+}
@@ -0,0 +1,104 @@
+package trackerr
+
+// Class classifies tracked errors by whether the operation that caused them
+// is worth retrying.
+type Class int
+
+const (
+	// Unclassified is the zero value for errors without a Class.
+	Unclassified Class = iota
+
+	// Transient errors are temporary, retrying may succeed.
+	Transient
+
+	// Throttled errors are temporary but caused by rate limiting so retries
+	// should back off for longer than transient ones.
+	Throttled
+
+	// Permanent errors will not go away by retrying.
+	Permanent
+)
+
+// String returns the name of the Class.
+func (c Class) String() string {
+	switch c {
+	case Transient:
+		return "transient"
+	case Throttled:
+		return "throttled"
+	case Permanent:
+		return "permanent"
+	default:
+		return "unclassified"
+	}
+}
+
+// Retryable returns true if the Class is Transient or Throttled.
+func (c Class) Retryable() bool {
+	return c == Transient || c == Throttled
+}
+
+// Transient classifies the tracked error as Transient then returns it.
+//
+//		var ErrTimeout = trackerr.New("Request timed out").Transient()
+//
+// Like Describe, it should only be called during package initialisation
+// because the Class is shared with all copies of the error.
+func (e *TrackedError) Transient() *TrackedError {
+	return e.classify(Transient)
+}
+
+// Throttled classifies the tracked error as Throttled then returns it.
+//
+//		var ErrRateLimited = trackerr.New("Too many requests").Throttled()
+//
+// Like Describe, it should only be called during package initialisation.
+func (e *TrackedError) Throttled() *TrackedError {
+	return e.classify(Throttled)
+}
+
+// Permanent classifies the tracked error as Permanent then returns it.
+//
+//		var ErrNotFound = trackerr.New("Not found").Permanent()
+//
+// Like Describe, it should only be called during package initialisation.
+func (e *TrackedError) Permanent() *TrackedError {
+	return e.classify(Permanent)
+}
+
+func (e *TrackedError) classify(c Class) *TrackedError {
+	if e.decl == nil {
+		e.decl = &declaration{}
+	}
+	e.decl.class = c
+	return e
+}
+
+// Class returns the error's own Class, not that of its causes. See Classify
+// for classifying a whole error stack.
+func (e TrackedError) Class() Class {
+	if e.decl == nil {
+		return Unclassified
+	}
+	return e.decl.class
+}
+
+// Classify returns the Class of the error stack by inspecting every tracked
+// error within it, including those on every branch of multi-cause errors.
+//
+// When the stack contains errors of different classes the most severe wins:
+// Permanent over Throttled over Transient over Unclassified. That is, if any
+// part of the failure is permanent retrying won't help, and if anything was
+// throttled then retries need to back off for longer.
+func Classify(e error) Class {
+	c := Unclassified
+
+	WalkStack(e, func(err error, _ int) bool {
+		if te, ok := asTracked(err); ok && te.Class() > c {
+			c = te.Class()
+		}
+		return c != Permanent
+	})
+
+	return c
+}
@@ -0,0 +1,52 @@
+package trackerr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Class_1(t *testing.T) {
+	r := IntRealm{}
+
+	a := r.New("a")
+	require.Equal(t, Unclassified, a.Class())
+
+	require.Same(t, a, a.Transient())
+	require.Equal(t, Transient, a.Class())
+	require.Equal(t, Transient, a.Because("b").(*TrackedError).Class())
+
+	require.Equal(t, Throttled, r.New("c").Throttled().Class())
+	require.Equal(t, Permanent, r.New("d").Permanent().Class())
+	require.Equal(t, Unclassified, (&TrackedError{}).Class())
+}
+
+func Test_Class_2(t *testing.T) {
+	require.Equal(t, "unclassified", Unclassified.String())
+	require.Equal(t, "transient", Transient.String())
+	require.Equal(t, "throttled", Throttled.String())
+	require.Equal(t, "permanent", Permanent.String())
+
+	require.False(t, Unclassified.Retryable())
+	require.True(t, Transient.Retryable())
+	require.True(t, Throttled.Retryable())
+	require.False(t, Permanent.Retryable())
+}
+
+func Test_Classify_1(t *testing.T) {
+	r := IntRealm{}
+	none := r.New("none")
+	transient := r.New("transient").Transient()
+	throttled := r.New("throttled").Throttled()
+	permanent := r.New("permanent").Permanent()
+
+	require.Equal(t, Unclassified, Classify(nil))
+	require.Equal(t, Unclassified, Classify(none.Because("a")))
+	require.Equal(t, Transient, Classify(none.CausedBy(transient)))
+	require.Equal(t, Throttled, Classify(transient.CausedBy(throttled)))
+	require.Equal(t, Throttled, Classify(throttled.CausedBy(transient)))
+	require.Equal(t, Permanent, Classify(transient.CausedBy(permanent)))
+	require.Equal(t, Permanent, Classify(permanent.CausedBy(throttled)))
+	require.Equal(t, Permanent, Classify(none.CausedBy(errors.Join(transient, permanent))))
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"io"
+
+	"github.com/PaulioRandall/go-trackerr"
+	"github.com/PaulioRandall/go-trackerr/refdoc"
+)
+
+func runDocs(args []string, stdout io.Writer) (e error) {
+	fs := newFlagSet("docs")
+	format := fs.String("format", "markdown", "Output format, 'markdown' or 'json'")
+	out := fs.String("o", "", "Output file, stdout if omitted")
+
+	if e := fs.Parse(args); e != nil {
+		return ErrUsage.CausedBy(e)
+	}
+
+	write, ok := map[string]func(io.Writer, []refdoc.Entry) error{
+		"markdown": refdoc.WriteMarkdown,
+		"md":       refdoc.WriteMarkdown,
+		"json":     refdoc.WriteJSON,
+	}[*format]
+
+	if !ok {
+		return ErrUsage.Because("Unknown format '%s'", *format)
+	}
+
+	dir := "."
+	if fs.NArg() > 0 {
+		dir = fs.Arg(0)
+	}
+
+	entries, e := refdoc.Scan(dir)
+	if e != nil {
+		return e
+	}
+
+	w, closeFile, e := openOutput(*out, stdout)
+	if e != nil {
+		return trackerr.Untracked("Could not create output file").CausedBy(e)
+	}
+
+	defer func() {
+		if closeErr := closeFile(); e == nil {
+			e = closeErr
+		}
+	}()
+
+	return write(w, entries)
+}
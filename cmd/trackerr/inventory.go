@@ -0,0 +1,266 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/PaulioRandall/go-trackerr"
+	"github.com/PaulioRandall/go-trackerr/refdoc"
+)
+
+var (
+	// ErrLoading is returned when a module's packages could not be loaded.
+	ErrLoading = trackerr.New("Failed to load packages")
+
+	// ErrCodeCollision is returned by the inventory command, in check mode,
+	// when multiple tracked errors are declared with the same stable code.
+	ErrCodeCollision = trackerr.New("Tracked error codes collide")
+)
+
+// inventory is the document written by the inventory command in JSON format.
+type inventory struct {
+	Errors     []refdoc.Entry `json:"errors"`
+	Duplicates []group        `json:"duplicates"`
+	Collisions []group        `json:"collisions"`
+}
+
+// group is a set of declarations sharing the same message or code.
+type group struct {
+	Value        string   `json:"value"`
+	Declarations []string `json:"declarations"`
+}
+
+func runInventory(args []string, stdout io.Writer) error {
+	fs := newFlagSet("inventory")
+	format := fs.String("format", "table", "Output format, 'table' or 'json'")
+	check := fs.Bool("check", false, "Fail if any stable codes collide")
+
+	if e := fs.Parse(args); e != nil {
+		return ErrUsage.CausedBy(e)
+	}
+
+	write, ok := map[string]func(io.Writer, inventory) error{
+		"table": writeInventoryTable,
+		"json":  writeInventoryJSON,
+	}[*format]
+
+	if !ok {
+		return ErrUsage.Because("Unknown format '%s'", *format)
+	}
+
+	dir := "."
+	if fs.NArg() > 0 {
+		dir = fs.Arg(0)
+	}
+
+	entries, e := loadEntries(dir)
+	if e != nil {
+		return e
+	}
+
+	inv := inventory{
+		Errors:     entries,
+		Duplicates: groupBy(entries, func(e refdoc.Entry) string { return e.Message }),
+		Collisions: groupBy(entries, func(e refdoc.Entry) string { return e.Code }),
+	}
+
+	if e := write(stdout, inv); e != nil {
+		return e
+	}
+
+	if *check && len(inv.Collisions) > 0 {
+		return ErrCodeCollision.Because("%d code(s) declared more than once", len(inv.Collisions))
+	}
+	return nil
+}
+
+// loadEntries loads all packages within the module rooted at dir and returns
+// their tracked error declarations ordered by file then line. If any package
+// has errors, such as syntax errors, then none are returned to avoid
+// reporting a partial inventory.
+func loadEntries(dir string) ([]refdoc.Entry, error) {
+	root, e := filepath.Abs(dir)
+	if e != nil {
+		return nil, ErrLoading.CausedBy(e)
+	}
+
+	pkgs, e := listPackages(root)
+	if e != nil {
+		return nil, ErrLoading.CausedBy(e)
+	}
+
+	var (
+		entries []refdoc.Entry
+		errs    []error
+		fset    = token.NewFileSet()
+	)
+
+	for _, p := range pkgs {
+		if p.Error != nil {
+			errs = append(errs, trackerr.Untracked(p.Error.Err))
+			continue
+		}
+
+		for _, name := range append(p.GoFiles, p.CgoFiles...) {
+			file := filepath.Join(p.Dir, name)
+
+			f, e := parser.ParseFile(fset, file, nil, parser.ParseComments)
+			if e != nil {
+				errs = append(errs, e)
+				continue
+			}
+
+			rel, e := filepath.Rel(root, file)
+			if e != nil {
+				return nil, ErrLoading.CausedBy(e)
+			}
+
+			entries = append(entries, refdoc.ScanFile(fset, f, p.ImportPath, filepath.ToSlash(rel))...)
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, ErrLoading.CausedByAll(errs...)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].File != entries[j].File {
+			return entries[i].File < entries[j].File
+		}
+		return entries[i].Line < entries[j].Line
+	})
+
+	return entries, nil
+}
+
+// listedPackage is the subset of 'go list -json' output used to find the
+// source files of a package.
+type listedPackage struct {
+	ImportPath string
+	Dir        string
+	GoFiles    []string
+	CgoFiles   []string
+	Error      *struct{ Err string }
+}
+
+// listPackages uses the go command to list the packages of the module rooted
+// at dir. Build constraints are applied so only the files that would be
+// compiled are returned.
+func listPackages(dir string) ([]listedPackage, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command("go", "list", "-e", "-json=ImportPath,Dir,GoFiles,CgoFiles,Error", "./...")
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if e := cmd.Run(); e != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, trackerr.Untracked(msg).CausedBy(e)
+		}
+		return nil, e
+	}
+
+	var pkgs []listedPackage
+	dec := json.NewDecoder(&stdout)
+
+	for dec.More() {
+		var p listedPackage
+		if e := dec.Decode(&p); e != nil {
+			return nil, e
+		}
+		pkgs = append(pkgs, p)
+	}
+
+	return pkgs, nil
+}
+
+// groupBy returns the groups of entries that share the same non-empty key.
+func groupBy(entries []refdoc.Entry, key func(refdoc.Entry) string) []group {
+	var keys []string
+	decls := map[string][]string{}
+
+	for _, e := range entries {
+		k := key(e)
+		if k == "" {
+			continue
+		}
+
+		if _, ok := decls[k]; !ok {
+			keys = append(keys, k)
+		}
+		decls[k] = append(decls[k], declName(e))
+	}
+
+	groups := []group{}
+	for _, k := range keys {
+		if len(decls[k]) > 1 {
+			groups = append(groups, group{Value: k, Declarations: decls[k]})
+		}
+	}
+
+	return groups
+}
+
+func declName(e refdoc.Entry) string {
+	return fmt.Sprintf("%s.%s", e.Package, e.Var)
+}
+
+func writeInventoryJSON(w io.Writer, inv inventory) error {
+	if inv.Errors == nil {
+		inv.Errors = []refdoc.Entry{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(inv)
+}
+
+// writeInventoryTable writes the inventory as an aligned table followed by
+// any duplicate messages and code collisions.
+//
+//		VARIABLE       PACKAGE              CODE     MESSAGE            POSITION
+//		ErrConnecting  example.com/app/db   DB-0042  Failed to connect  db/db.go:9
+func writeInventoryTable(w io.Writer, inv inventory) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VARIABLE\tPACKAGE\tCODE\tMESSAGE\tPOSITION")
+
+	for _, e := range inv.Errors {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s:%d\n",
+			e.Var,
+			e.Package,
+			e.Code,
+			e.Message,
+			e.File,
+			e.Line,
+		)
+	}
+
+	if e := tw.Flush(); e != nil {
+		return e
+	}
+
+	writeGroups(w, "Duplicate messages", inv.Duplicates)
+	writeGroups(w, "Code collisions", inv.Collisions)
+	return nil
+}
+
+func writeGroups(w io.Writer, title string, groups []group) {
+	if len(groups) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\n%s:\n", title)
+	for _, g := range groups {
+		fmt.Fprintf(w, "  %q: %s\n", g.Value, strings.Join(g.Declarations, ", "))
+	}
+}
@@ -0,0 +1,114 @@
+// Command trackerr provides tooling for modules that use trackerr.
+//
+//		trackerr docs [-format markdown|json] [-o file] [dir]
+//		trackerr inventory [-format table|json] [-check] [dir]
+//		trackerr parse [-format pretty|json] [-catalogue file] [-header text] [file]
+//
+// The docs command generates reference documentation for every tracked error
+// declared within the module rooted at dir, the current directory by default.
+//
+// The inventory command loads the module's packages and lists every tracked
+// error declared within them along with any duplicated messages and colliding
+// codes. With -check it fails if any codes collide.
+//
+// The parse command reads error stack text, such as that printed by
+// trackerr.Debug, from file or stdin and prints its structure. Codes are
+// resolved against a JSON catalogue generated by the docs command. Debug
+// headers configured via trackerr.SetDebugHeader can be skipped with -header.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/PaulioRandall/go-trackerr"
+)
+
+var (
+	// ErrUsage is returned when the command line arguments are invalid.
+	ErrUsage = trackerr.New("Incorrect usage")
+
+	// ErrCommand is returned when a command fails to complete.
+	ErrCommand = trackerr.New("Command failed")
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string, stdout io.Writer) error
+}
+
+var commands = []command{
+	{
+		name:  "docs",
+		usage: "docs [-format markdown|json] [-o file] [dir]",
+		run:   runDocs,
+	},
+	{
+		name:  "inventory",
+		usage: "inventory [-format table|json] [-check] [dir]",
+		run:   runInventory,
+	},
+	{
+		name:  "parse",
+		usage: "parse [-format pretty|json] [-catalogue file] [-header text] [file]",
+		run:   runParse,
+	},
+}
+
+func main() {
+	if e := run(os.Args[1:], os.Stdout); e != nil {
+		fmt.Fprint(os.Stderr, trackerr.ErrorStack(e))
+
+		if trackerr.Is(e, ErrUsage) {
+			printUsage(os.Stderr)
+			os.Exit(2)
+		}
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return ErrUsage.Because("No command specified")
+	}
+
+	for _, c := range commands {
+		if c.name == args[0] {
+			if e := c.run(args[1:], stdout); e != nil {
+				return ErrCommand.BecauseOf(e, "%s command failed", c.name)
+			}
+			return nil
+		}
+	}
+
+	return ErrUsage.Because("Unknown command '%s'", args[0])
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage:")
+	for _, c := range commands {
+		fmt.Fprintln(w, "\ttrackerr "+c.usage)
+	}
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+// openOutput returns stdout if file is empty otherwise the file is created.
+func openOutput(file string, stdout io.Writer) (io.Writer, func() error, error) {
+	if file == "" {
+		return stdout, func() error { return nil }, nil
+	}
+
+	f, e := os.Create(file)
+	if e != nil {
+		return nil, nil, e
+	}
+	return f, f.Close, nil
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulioRandall/go-trackerr"
+)
+
+func TestMain(m *testing.M) {
+	// The inventory fixtures are modules of their own so they must be loaded
+	// outside of any local workspace.
+	os.Setenv("GOWORK", "off")
+	os.Exit(m.Run())
+}
+
+func Test_run_1(t *testing.T) {
+	e := run(nil, &strings.Builder{})
+	require.True(t, trackerr.Is(e, ErrUsage))
+
+	e = run([]string{"abc"}, &strings.Builder{})
+	require.True(t, trackerr.Is(e, ErrUsage))
+}
+
+func Test_runDocs_1(t *testing.T) {
+	sb := strings.Builder{}
+
+	e := run([]string{"docs", "-format", "json", "../../refdoc/testdata/example"}, &sb)
+	require.Nil(t, e)
+
+	require.Contains(t, sb.String(), `"code": "DB-0042"`)
+	require.Contains(t, sb.String(), `"variable": "ErrStarting"`)
+}
+
+func Test_runDocs_2(t *testing.T) {
+	e := run([]string{"docs", "-format", "abc"}, &strings.Builder{})
+	require.True(t, trackerr.AllOrdered(e, ErrCommand, ErrUsage))
+}
+
+func Test_runInventory_1(t *testing.T) {
+	sb := strings.Builder{}
+
+	e := run([]string{"inventory", "testdata/inventory"}, &sb)
+	require.Nil(t, e)
+
+	exp := `VARIABLE     PACKAGE                 CODE      MESSAGE          POSITION
+ErrNotFound  example.com/inv/orders  INV-0001  Order not found  orders/orders.go:8
+ErrMissing   example.com/inv/orders            Invalid input    orders/orders.go:9
+ErrPaying    example.com/inv/orders            Payment failed   orders/orders.go:10
+ErrNotFound  example.com/inv/users   INV-0001  Not found        users/users.go:8
+ErrInvalid   example.com/inv/users             Invalid input    users/users.go:9
+
+Duplicate messages:
+  "Invalid input": example.com/inv/orders.ErrMissing, example.com/inv/users.ErrInvalid
+
+Code collisions:
+  "INV-0001": example.com/inv/orders.ErrNotFound, example.com/inv/users.ErrNotFound
+`
+
+	require.Equal(t, exp, sb.String())
+}
+
+func Test_runInventory_2(t *testing.T) {
+	sb := strings.Builder{}
+
+	e := run([]string{"inventory", "-format", "json", "-check", "testdata/inventory"}, &sb)
+	require.True(t, trackerr.AllOrdered(e, ErrCommand, ErrCodeCollision))
+
+	inv := inventory{}
+	require.Nil(t, json.Unmarshal([]byte(sb.String()), &inv))
+
+	require.Len(t, inv.Errors, 5)
+	require.Equal(t, []group{{
+		Value:        "Invalid input",
+		Declarations: []string{"example.com/inv/orders.ErrMissing", "example.com/inv/users.ErrInvalid"},
+	}}, inv.Duplicates)
+	require.Equal(t, []group{{
+		Value:        "INV-0001",
+		Declarations: []string{"example.com/inv/orders.ErrNotFound", "example.com/inv/users.ErrNotFound"},
+	}}, inv.Collisions)
+}
+
+func Test_runInventory_3(t *testing.T) {
+	sb := strings.Builder{}
+
+	e := run([]string{"inventory", "-check", "../../refdoc/testdata/example"}, &sb)
+	require.Nil(t, e)
+	require.NotContains(t, sb.String(), "Code collisions")
+
+	e = run([]string{"inventory", "-format", "abc"}, &strings.Builder{})
+	require.True(t, trackerr.AllOrdered(e, ErrCommand, ErrUsage))
+}
+
+func Test_runInventory_4(t *testing.T) {
+	sb := strings.Builder{}
+
+	e := run([]string{"inventory", "-check", "testdata/broken"}, &sb)
+	require.True(t, trackerr.AllOrdered(e, ErrCommand, ErrLoading))
+	require.Contains(t, trackerr.ErrorStack(e), "broken.go:9")
+	require.Empty(t, sb.String())
+}
+
+func Test_runParse_1(t *testing.T) {
+	catalogue := filepath.Join(t.TempDir(), "errors.json")
+
+	e := run([]string{"docs", "-format", "json", "-o", catalogue, "../../refdoc/testdata/example"}, &strings.Builder{})
+	require.Nil(t, e)
+
+	sb := strings.Builder{}
+	e = run([]string{"parse", "-catalogue", catalogue, "testdata/parse/stack.txt"}, &sb)
+	require.Nil(t, e)
+
+	exp := `#0 Request failed
+   site: main.run main.go:12
+   attrs: req=abc
+#1 [DB-0042] Failed to connect
+   declared: example.com/app/db.ErrConnecting db/db.go:9
+   description: ErrConnecting is returned when the database can't be reached.
+  #2 a
+     at x.y y.go:1
+  #3 b
+`
+
+	require.Equal(t, exp, sb.String())
+}
+
+func Test_runParse_2(t *testing.T) {
+	sb := strings.Builder{}
+
+	e := run([]string{"parse", "-format", "json", "testdata/parse/stack.txt"}, &sb)
+	require.Nil(t, e)
+
+	var frames []parsedFrame
+	require.Nil(t, json.Unmarshal([]byte(sb.String()), &frames))
+
+	require.Len(t, frames, 4)
+	require.Equal(t, "DB-0042", frames[1].Code)
+	require.Nil(t, frames[1].Declaration)
+	require.Equal(t, 1, frames[3].Level)
+}
+
+func Test_runParse_3(t *testing.T) {
+	empty := filepath.Join(t.TempDir(), "empty.txt")
+	require.Nil(t, os.WriteFile(empty, []byte("\n"), 0o644))
+
+	e := run([]string{"parse", empty}, &strings.Builder{})
+	require.True(t, trackerr.AllOrdered(e, ErrCommand, ErrNoStack))
+
+	e = run([]string{"parse", "-format", "abc"}, &strings.Builder{})
+	require.True(t, trackerr.AllOrdered(e, ErrCommand, ErrUsage))
+}
+
+func Test_runParse_4(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "nil.txt")
+	require.Nil(t, os.WriteFile(input, []byte("[MY ERRORS] nil error\n"), 0o644))
+
+	e := run([]string{"parse", "-header", "[MY ERRORS]", input}, &strings.Builder{})
+	require.True(t, trackerr.AllOrdered(e, ErrCommand, ErrNoStack))
+
+	e = run([]string{"parse", input}, &strings.Builder{})
+	require.Nil(t, e)
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/PaulioRandall/go-trackerr"
+	"github.com/PaulioRandall/go-trackerr/refdoc"
+)
+
+// ErrNoStack is returned by the parse command when the input contains no
+// error stack.
+var ErrNoStack = trackerr.New("No error stack found")
+
+// parsedFrame is a trackerr.ParsedFrame with the declaration of its stable code
+// if it was found in the catalogue.
+type parsedFrame struct {
+	trackerr.ParsedFrame
+	Declaration *refdoc.Entry `json:"declaration,omitempty"`
+}
+
+func runParse(args []string, stdout io.Writer) (e error) {
+	fs := newFlagSet("parse")
+	format := fs.String("format", "pretty", "Output format, 'pretty' or 'json'")
+	catFile := fs.String("catalogue", "", "JSON catalogue, as generated by the docs command, to resolve codes with")
+	header := fs.String("header", "", "Debug header, as configured via trackerr.SetDebugHeader, to skip")
+
+	if e := fs.Parse(args); e != nil {
+		return ErrUsage.CausedBy(e)
+	}
+
+	write, ok := map[string]func(io.Writer, []parsedFrame) error{
+		"pretty": writeFramesPretty,
+		"json":   writeFramesJSON,
+	}[*format]
+
+	if !ok {
+		return ErrUsage.Because("Unknown format '%s'", *format)
+	}
+
+	input, e := readInput(fs.Arg(0))
+	if e != nil {
+		return trackerr.Untracked("Could not read input").CausedBy(e)
+	}
+
+	catalogue, e := readCatalogue(*catFile)
+	if e != nil {
+		return trackerr.Untracked("Could not read catalogue").CausedBy(e)
+	}
+
+	parsed := trackerr.ParseStack(input, *header)
+	if len(parsed) == 0 {
+		return ErrNoStack
+	}
+
+	frames := make([]parsedFrame, len(parsed))
+	for i, p := range parsed {
+		frames[i].ParsedFrame = p
+
+		if entry, ok := catalogue[p.Code]; ok && p.Code != "" {
+			frames[i].Declaration = &entry
+		}
+	}
+
+	return write(stdout, frames)
+}
+
+// readInput reads the whole file or stdin if file is empty or '-'.
+func readInput(file string) (string, error) {
+	var b []byte
+	var e error
+
+	if file == "" || file == "-" {
+		b, e = io.ReadAll(os.Stdin)
+	} else {
+		b, e = os.ReadFile(file)
+	}
+
+	return string(b), e
+}
+
+// readCatalogue returns the entries of the catalogue file by code. An empty
+// map is returned if file is empty.
+func readCatalogue(file string) (map[string]refdoc.Entry, error) {
+	entries := map[string]refdoc.Entry{}
+	if file == "" {
+		return entries, nil
+	}
+
+	b, e := os.ReadFile(file)
+	if e != nil {
+		return nil, e
+	}
+
+	var c refdoc.Catalogue
+	if e := json.Unmarshal(b, &c); e != nil {
+		return nil, e
+	}
+
+	for _, entry := range c.Errors {
+		if entry.Code != "" {
+			entries[entry.Code] = entry
+		}
+	}
+
+	return entries, nil
+}
+
+func writeFramesJSON(w io.Writer, frames []parsedFrame) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(frames)
+}
+
+// writeFramesPretty writes each frame as a numbered heading followed by its
+// details, indented by its branch level.
+//
+//		#0 [DB-0042] Failed to connect
+//		   site: db.Open db.go:24
+//		   attrs: host=localhost
+//		   declared: example.com/app/db.ErrConnecting db/db.go:9
+//		   description: The database could not be reached.
+func writeFramesPretty(w io.Writer, frames []parsedFrame) error {
+	sb := strings.Builder{}
+
+	for i, f := range frames {
+		indent := strings.Repeat("  ", f.Level)
+		head := fmt.Sprintf("#%d ", i)
+		pad := indent + strings.Repeat(" ", len(head))
+
+		msg := f.Message
+		if f.Checkpoint {
+			msg = "*** " + msg + " ***"
+		}
+		if f.Code != "" {
+			msg = "[" + f.Code + "] " + msg
+		}
+
+		sb.WriteString(indent + head + strings.ReplaceAll(msg, "\n", "\n"+pad) + "\n")
+
+		if f.Site != "" {
+			sb.WriteString(pad + "site: " + f.Site + "\n")
+		}
+
+		if len(f.Attrs) > 0 {
+			sb.WriteString(pad + "attrs: " + fmtAttrs(f.Attrs) + "\n")
+		}
+
+		for _, t := range f.Trace {
+			sb.WriteString(pad + "at " + t + "\n")
+		}
+
+		if d := f.Declaration; d != nil {
+			sb.WriteString(fmt.Sprintf("%sdeclared: %s.%s %s:%d\n", pad, d.Package, d.Var, d.File, d.Line))
+
+			if d.Description != "" {
+				sb.WriteString(pad + "description: " + d.Description + "\n")
+			}
+		}
+	}
+
+	_, e := io.WriteString(w, sb.String())
+	return e
+}
+
+func fmtAttrs(attrs map[string]string) string {
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for i, k := range keys {
+		keys[i] = k + "=" + attrs[k]
+	}
+	return strings.Join(keys, ", ")
+}
@@ -0,0 +1,10 @@
+package broken
+
+import (
+	"github.com/PaulioRandall/go-trackerr"
+)
+
+var (
+	ErrNotFound = trackerr.Coded("BRK-0001", "Not found")
+	ErrInvalid  = trackerr.New("Invalid input"
+)
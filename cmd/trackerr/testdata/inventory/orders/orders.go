@@ -0,0 +1,11 @@
+package orders
+
+import (
+	"github.com/PaulioRandall/go-trackerr"
+)
+
+var (
+	ErrNotFound = trackerr.Coded("INV-0001", "Order not found")
+	ErrMissing  = trackerr.New("Invalid input")
+	ErrPaying   = trackerr.Track("Payment failed")
+)
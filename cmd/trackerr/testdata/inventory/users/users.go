@@ -0,0 +1,10 @@
+package users
+
+import (
+	"github.com/PaulioRandall/go-trackerr"
+)
+
+var (
+	ErrNotFound = trackerr.Coded("INV-0001", "Not found")
+	ErrInvalid  = trackerr.New("Invalid input")
+)
@@ -0,0 +1,188 @@
+package trackerr
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultDebugHeader is the header printed by Debug unless changed via
+// SetDebugHeader.
+const DefaultDebugHeader = "[DEBUG ERROR]"
+
+// DebugEvent is passed to the DebugSink each time Debug is called.
+type DebugEvent struct {
+	// Header is the configured debug header which may be empty.
+	Header string
+
+	// Stack is the error stack formatted with the configured ErrorFormatter
+	// or empty if Err is nil.
+	Stack string
+
+	// Err is the error passed to Debug which may be nil.
+	Err error
+}
+
+// String returns the text Debug prints by default.
+//
+//		[DEBUG ERROR]
+//		  Failed to load data
+//		⤷ Could not open database
+func (ev DebugEvent) String() string {
+	if ev.Stack == "" {
+		if ev.Header == "" {
+			return "nil error"
+		}
+		return ev.Header + " nil error"
+	}
+
+	if ev.Header == "" {
+		return ev.Stack
+	}
+	return ev.Header + "\n  " + ev.Stack
+}
+
+// DebugSink receives the output of Debug and DebugPanic.
+//
+//		trackerr.SetDebugSink(func(ev trackerr.DebugEvent) (int, error) {
+//			return fmt.Fprint(os.Stderr, ev)
+//		})
+type DebugSink func(ev DebugEvent) (int, error)
+
+// WriterSink returns a DebugSink that writes each DebugEvent's text to w.
+func WriterSink(w io.Writer) DebugSink {
+	return func(ev DebugEvent) (int, error) {
+		return io.WriteString(w, ev.String())
+	}
+}
+
+// SlogSink returns a DebugSink that logs each DebugEvent to l at the level.
+// The header is used as the log message and the error is logged under the
+// key 'error' so errors from this package are logged as structured groups,
+// see StackValue.
+//
+//		trackerr.SetDebugSink(trackerr.SlogSink(logger, slog.LevelDebug))
+//
+// The int returned by the sink is always zero.
+func SlogSink(l *slog.Logger, level slog.Level) DebugSink {
+	return func(ev DebugEvent) (int, error) {
+		l.Log(context.Background(), level, ev.Header, slog.Any("error", ev.Err))
+		return 0, nil
+	}
+}
+
+type debugConfig struct {
+	sink   DebugSink
+	header string
+	f      ErrorFormatter
+}
+
+var (
+	debugMu     sync.Mutex
+	globalDebug atomic.Pointer[debugConfig]
+)
+
+// SetDebugSink sets the DebugSink used by Debug and DebugPanic. Passing nil
+// reverts to printing to stdout. It's safe to call concurrently with Debug.
+func SetDebugSink(s DebugSink) {
+	updateDebug(func(c *debugConfig) {
+		c.sink = s
+	})
+}
+
+// SetDebugHeader sets the header printed by Debug and DebugPanic before the
+// error stack. An empty header means none is printed.
+func SetDebugHeader(h string) {
+	updateDebug(func(c *debugConfig) {
+		c.header = h
+	})
+}
+
+// SetDebugFormatter sets the ErrorFormatter used by Debug and DebugPanic to
+// format the error stack. Passing nil reverts to DefaultFormatter.
+//
+//		trackerr.SetDebugFormatter(trackerr.VerboseFormatter)
+func SetDebugFormatter(f ErrorFormatter) {
+	updateDebug(func(c *debugConfig) {
+		c.f = f
+	})
+}
+
+func updateDebug(update func(c *debugConfig)) {
+	debugMu.Lock()
+	defer debugMu.Unlock()
+
+	c := *loadDebug()
+	update(&c)
+	globalDebug.Store(&c)
+}
+
+func loadDebug() *debugConfig {
+	if c := globalDebug.Load(); c != nil {
+		return c
+	}
+
+	return &debugConfig{
+		header: DefaultDebugHeader,
+	}
+}
+
+// Debug pretty prints the error stack trace to terminal for debugging
+// purposes.
+//
+// If e is nil then a message will be printed indicating so. This function is
+// not designed for logging, just day to day manual debugging.
+//
+// Output can be redirected via SetDebugSink and customised via
+// SetDebugHeader and SetDebugFormatter.
+func Debug(e error) (int, error) {
+	c := loadDebug()
+
+	f := c.f
+	if f == nil {
+		f = DefaultFormatter
+	}
+
+	ev := DebugEvent{
+		Header: c.header,
+		Stack:  ErrorStackf(e, f),
+		Err:    e,
+	}
+
+	if c.sink == nil {
+		return fmt.Print(ev)
+	}
+	return c.sink(ev)
+}
+
+// DebugPanic recovers from a panic, prints out the error using the Debug
+// function, and finally sets it as the catch error's pointer value.
+//
+// If nil is passed as the catch then the panic continues after printing.
+//
+// If the panic value is not an error the panic will continue!
+//
+// This function is not designed for logging, just day to day manual debugging.
+// See CatchPanic for recovering from panics in production.
+func DebugPanic(catch *error) {
+	v := recover()
+
+	if v == nil {
+		return
+	}
+
+	e, ok := v.(error)
+	if !ok {
+		panic(v)
+	}
+
+	Debug(e)
+
+	if catch == nil {
+		panic(e)
+	}
+	*catch = e
+}
@@ -0,0 +1,115 @@
+package trackerr
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// withDebugSink redirects Debug output to the returned builder until the
+// returned function is called.
+func withDebugSink() (*strings.Builder, func()) {
+	sb := &strings.Builder{}
+	SetDebugSink(WriterSink(sb))
+
+	return sb, func() {
+		SetDebugSink(nil)
+		SetDebugHeader(DefaultDebugHeader)
+		SetDebugFormatter(nil)
+	}
+}
+
+func Test_Debug_1(t *testing.T) {
+	sb, reset := withDebugSink()
+	defer reset()
+
+	r := IntRealm{}
+	e := r.New("a").CausedBy(Untracked("b"))
+
+	_, err := Debug(e)
+	require.Nil(t, err)
+	require.Equal(t, "[DEBUG ERROR]\n  a\n⤷ b\n", sb.String())
+
+	sb.Reset()
+	Debug(nil)
+	require.Equal(t, "[DEBUG ERROR] nil error", sb.String())
+}
+
+func Test_Debug_2(t *testing.T) {
+	sb, reset := withDebugSink()
+	defer reset()
+
+	r := IntRealm{}
+	e := r.Coded("ABC-1", "a").CausedBy(Untracked("b"))
+
+	SetDebugHeader("")
+	SetDebugFormatter(FormatCode(DefaultFormatter))
+
+	Debug(e)
+	require.Equal(t, "[ABC-1] a\n⤷ b\n", sb.String())
+
+	sb.Reset()
+	SetDebugHeader("[MY APP]")
+	Debug(nil)
+	require.Equal(t, "[MY APP] nil error", sb.String())
+}
+
+func Test_Debug_3(t *testing.T) {
+	_, reset := withDebugSink()
+	defer reset()
+
+	var act []DebugEvent
+	SetDebugSink(func(ev DebugEvent) (int, error) {
+		act = append(act, ev)
+		return 1, nil
+	})
+
+	a := Untracked("a")
+	n, err := Debug(a)
+
+	require.Equal(t, 1, n)
+	require.Nil(t, err)
+	require.Equal(t, []DebugEvent{{Header: DefaultDebugHeader, Stack: "a\n", Err: a}}, act)
+}
+
+func Test_Debug_4(t *testing.T) {
+	_, reset := withDebugSink()
+	defer reset()
+
+	buf := bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	SetDebugSink(SlogSink(logger, slog.LevelDebug))
+
+	Debug(Untracked("abc"))
+
+	require.Contains(t, buf.String(), `level=DEBUG msg="[DEBUG ERROR]"`)
+	require.Contains(t, buf.String(), `error.msg=abc`)
+}
+
+func Test_Debug_5(t *testing.T) {
+	_, reset := withDebugSink()
+	defer reset()
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			SetDebugSink(WriterSink(&strings.Builder{}))
+			SetDebugHeader("header")
+		}()
+
+		go func() {
+			defer wg.Done()
+			SetDebugSink(func(DebugEvent) (int, error) { return 0, nil })
+			Debug(Untracked("a"))
+		}()
+	}
+
+	wg.Wait()
+}
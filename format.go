@@ -0,0 +1,140 @@
+package trackerr
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DefaultFormatter is the ErrorFormatter used by ErrorStack.
+//
+//		Workflow error
+//		⤷ Failed to read data
+//		⤷ Error handling CSV file
+func DefaultFormatter(errMsg string, e error, isFirst bool) string {
+	sb := strings.Builder{}
+
+	if !isFirst {
+		sb.WriteString("⤷ ")
+	}
+
+	sb.WriteString(errMsg)
+	return sb.String()
+}
+
+// FormatCode returns an ErrorFormatter that prefixes the message of each
+// tracked error that has a code with that code before passing it on to f.
+//
+//		s := trackerr.ErrorStackf(e, trackerr.FormatCode(trackerr.DefaultFormatter))
+//
+//		// [APP-0001] Workflow error
+//		// ⤷ Failed to read data
+//		// ⤷ [DB-0042] Failed to connect
+func FormatCode(f ErrorFormatter) ErrorFormatter {
+	return func(errMsg string, e error, isFirst bool) string {
+		if te, ok := asTracked(e); ok && te.code != "" {
+			errMsg = "[" + te.code + "] " + errMsg
+		}
+
+		return applyFormatter(f, errMsg, e, isFirst)
+	}
+}
+
+func applyFormatter(f ErrorFormatter, errMsg string, e error, isFirst bool) string {
+	if f == nil {
+		return errMsg
+	}
+	return f(errMsg, e, isFirst)
+}
+
+// VerboseFormatter is the ErrorFormatter used when printing errors from this
+// package with the '%+v' verb. It combines the default formatting with
+// FormatCode, FormatCallSite, and FormatAttrs.
+//
+//		[APP-0001] Workflow error {user=42} @ main.run main.go:24
+//		⤷ Failed to read data @ data.Load load.go:12
+func VerboseFormatter(errMsg string, e error, isFirst bool) string {
+	return verboseFormatter(errMsg, e, isFirst)
+}
+
+var verboseFormatter = FormatAttrs(FormatCallSite(FormatCode(DefaultFormatter)))
+
+// Format satisfies fmt.Formatter.
+//
+//		%s, %v    The error's own message, the same as Error
+//		%q        The error's own message quoted
+//		%+v       The full error stack formatted with VerboseFormatter
+//		%#v       A Go syntax representation including tracking IDs
+//
+// Other verbs, flags, width, and precision are applied to the result of Error
+// as if it were a string, e.g. %x prints the message in hexadecimal.
+func (e TrackedError) Format(s fmt.State, verb rune) {
+	formatError(s, verb, &e)
+}
+
+// Format satisfies fmt.Formatter.
+//
+//		%s, %v    The error's own message, the same as Error
+//		%q        The error's own message quoted
+//		%+v       The full error stack formatted with VerboseFormatter
+//		%#v       A Go syntax representation
+//
+// Other verbs, flags, width, and precision are applied to the result of Error
+// as if it were a string, e.g. %x prints the message in hexadecimal.
+func (e UntrackedError) Format(s fmt.State, verb rune) {
+	formatError(s, verb, &e)
+}
+
+func formatError(s fmt.State, verb rune, e error) {
+	switch {
+	case verb == 'v' && s.Flag('+'):
+		stack := ErrorStackf(e, VerboseFormatter)
+		io.WriteString(s, strings.TrimSuffix(stack, "\n"))
+
+	case verb == 'v' && s.Flag('#'):
+		io.WriteString(s, goSyntax(e))
+
+	default:
+		fmt.Fprintf(s, fmt.FormatString(s, verb), e.Error())
+	}
+}
+
+func goSyntax(e error) string {
+	sb := strings.Builder{}
+
+	field := func(name string, v any) {
+		sb.WriteString(", ")
+		sb.WriteString(name)
+		sb.WriteRune(':')
+		fmt.Fprintf(&sb, "%#v", v)
+	}
+
+	if te, ok := asTracked(e); ok {
+		e = te
+	}
+
+	switch v := e.(type) {
+	case *TrackedError:
+		sb.WriteString("&trackerr.TrackedError{")
+		fmt.Fprintf(&sb, "id:%d", v.id)
+		if v.code != "" {
+			field("code", v.code)
+		}
+		field("msg", v.msg)
+		if v.attrs != nil {
+			field("attrs", v.attrs)
+		}
+		field("cause", v.cause)
+
+	case *UntrackedError:
+		sb.WriteString("&trackerr.UntrackedError{")
+		fmt.Fprintf(&sb, "msg:%#v", v.msg)
+		if v.attrs != nil {
+			field("attrs", v.attrs)
+		}
+		field("cause", v.cause)
+	}
+
+	sb.WriteRune('}')
+	return sb.String()
+}
@@ -0,0 +1,91 @@
+package trackerr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FormatCode_1(t *testing.T) {
+	r := IntRealm{}
+
+	abc := r.Coded("ABC-1", "abc")
+	efg := r.New("efg")
+	hij := r.Coded("HIJ-1", "hij")
+
+	e := abc.CausedBy(hij, efg)
+	act := ErrorStackf(e, FormatCode(DefaultFormatter))
+
+	expLines := []string{
+		"[ABC-1] abc",
+		"⤷ efg",
+		"⤷ [HIJ-1] hij",
+		"",
+	}
+	exp := strings.Join(expLines, "\n")
+
+	require.Equal(t, exp, act)
+}
+
+func Test_FormatCode_2(t *testing.T) {
+	r := IntRealm{}
+	e := r.Coded("ABC-1", "abc")
+
+	act := ErrorStackf(e, FormatCode(nil))
+	require.Equal(t, "[ABC-1] abc\n", act)
+}
+
+func Test_Format_1(t *testing.T) {
+	r := IntRealm{}
+	e := r.Coded("ABC-1", "abc").CausedBy(Untracked("efg", KV("x", 1)))
+
+	require.Equal(t, "abc", fmt.Sprintf("%v", e))
+	require.Equal(t, "abc", fmt.Sprintf("%s", e))
+	require.Equal(t, `"abc"`, fmt.Sprintf("%q", e))
+	require.Equal(t, "efg", fmt.Sprintf("%v", Unwrap(e)))
+	require.Equal(t, "%!d(string=abc)", fmt.Sprintf("%d", e))
+}
+
+func Test_Format_4(t *testing.T) {
+	e := Untracked("abc").CausedBy(Untracked("efg"))
+
+	require.Equal(t, "616263", fmt.Sprintf("%x", e))
+	require.Equal(t, "616263", fmt.Sprintf("%x", New("abc")))
+	require.Equal(t, "  abc", fmt.Sprintf("%5s", e))
+	require.Equal(t, "ab   ", fmt.Sprintf("%-5.2v", e))
+	require.Equal(t, `  "abc"`, fmt.Sprintf("%7q", e))
+}
+
+func Test_Format_2(t *testing.T) {
+	r := IntRealm{}
+	e := r.Coded("ABC-1", "abc").CausedBy(Untracked("efg", KV("x", 1)))
+
+	expLines := []string{
+		"[ABC-1] abc",
+		"⤷ efg {x=1}",
+	}
+	exp := strings.Join(expLines, "\n")
+
+	require.Equal(t, exp, fmt.Sprintf("%+v", e))
+
+	r.SetCapture(CaptureCaller)
+	e = r.New("hij").Because("klm")
+	require.Regexp(t, `^hij @ go-trackerr\.Test_Format_2 format_test\.go:\d+\n⤷ klm$`, fmt.Sprintf("%+v", e))
+}
+
+func Test_Format_3(t *testing.T) {
+	r := IntRealm{}
+	e := r.Coded("ABC-1", "abc").CausedBy(
+		Untracked("efg", KV("x", 1)).CausedBy(errors.New("hij")),
+	)
+
+	exp := `&trackerr.TrackedError{id:1, code:"ABC-1", msg:"abc", cause:` +
+		`&trackerr.UntrackedError{msg:"efg", attrs:[]trackerr.Attr{trackerr.Attr{Key:"x", Value:1, Sensitive:false}}, cause:` +
+		`&errors.errorString{s:"hij"}}}`
+
+	require.Equal(t, exp, fmt.Sprintf("%#v", e))
+	require.Equal(t, `&trackerr.UntrackedError{msg:"abc", cause:<nil>}`, fmt.Sprintf("%#v", Untracked("abc")))
+}
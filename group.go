@@ -0,0 +1,155 @@
+package trackerr
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
+
+// ErrPanic is returned when a panic is recovered by CatchPanic or a Group.
+// Its cause is an untracked error holding the panic value and the stack of
+// the goroutine that panicked.
+var ErrPanic = New("Recovered from panic")
+
+// CatchPanic recovers from any panic, converts it into an ErrPanic error, and
+// sets it as the catch error's pointer value. It must be deferred.
+//
+//		func work() (e error) {
+//			defer trackerr.CatchPanic(&e)
+//			...
+//		}
+//
+// The cause of the ErrPanic has the panic value as its message and sensitive
+// attributes 'panic' and 'stack' holding the raw panic value and goroutine
+// stack. If the panic value is an error it becomes the cause of the untracked
+// error, so errors.Is still works, and the message is simply 'panic value' so
+// the error's message isn't repeated.
+//
+// Unlike DebugPanic, nothing is printed and the panic never continues.
+func CatchPanic(catch *error) {
+	v := recover()
+
+	if v == nil {
+		return
+	}
+
+	*catch = panicError(v, debug.Stack())
+}
+
+func panicError(v any, stack []byte) error {
+	u := &UntrackedError{
+		msg: fmt.Sprint(v),
+		attrs: []Attr{
+			Sensitive("panic", v),
+			Sensitive("stack", string(stack)),
+		},
+	}
+
+	if e, ok := v.(error); ok {
+		u.msg = "panic value"
+		u.cause = e
+	}
+
+	return ErrPanic.CausedBy(u)
+}
+
+// Group runs functions in their own goroutines, recovering from any panics,
+// and collects their errors. It's similar to errgroup.Group.
+//
+//		g, ctx := trackerr.WithContext(ctx)
+//
+//		for _, job := range jobs {
+//			g.Go(func() error {
+//				return job.Run(ctx)
+//			})
+//		}
+//
+//		if e := g.Wait(); e != nil {
+//			...
+//		}
+//
+// Panics are converted into ErrPanic errors via CatchPanic. A zero Group is
+// valid and does not cancel on error.
+type Group struct {
+	cancel func(error)
+	wg     sync.WaitGroup
+
+	mu   sync.Mutex
+	errs []error
+}
+
+// WithContext returns a new Group and a derived context. The context is
+// cancelled, with the error as its cause, the first time a function passed to
+// Go returns an error or panics, or when Wait or WaitAll returns, whichever
+// occurs first.
+func WithContext(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	return &Group{cancel: cancel}, ctx
+}
+
+// Go calls f in a new goroutine.
+func (g *Group) Go(f func() error) {
+	g.wg.Add(1)
+
+	go func() {
+		defer g.wg.Done()
+
+		if e := callCatchingPanic(f); e != nil {
+			g.fail(e)
+		}
+	}()
+}
+
+func callCatchingPanic(f func() error) (e error) {
+	defer CatchPanic(&e)
+	return f()
+}
+
+func (g *Group) fail(e error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if len(g.errs) == 0 && g.cancel != nil {
+		g.cancel(e)
+	}
+	g.errs = append(g.errs, e)
+}
+
+// Wait blocks until all functions passed to Go have returned then returns
+// the first error, if any.
+func (g *Group) Wait() error {
+	errs := g.wait()
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs[0]
+}
+
+// WaitAll blocks until all functions passed to Go have returned then returns
+// all errors, in the order they occurred, as a MultiError. Nil is returned if
+// there were no errors.
+func (g *Group) WaitAll() error {
+	errs := g.wait()
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return Multi(errs...)
+}
+
+func (g *Group) wait() []error {
+	g.wg.Wait()
+
+	if g.cancel != nil {
+		g.cancel(nil)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	errs := make([]error, len(g.errs))
+	copy(errs, g.errs)
+	return errs
+}
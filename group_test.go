@@ -0,0 +1,119 @@
+package trackerr
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CatchPanic_1(t *testing.T) {
+	f := func() (e error) {
+		defer CatchPanic(&e)
+		panic("abc")
+	}
+
+	e := f()
+	require.True(t, errors.Is(e, ErrPanic))
+
+	u, ok := Unwrap(e).(*UntrackedError)
+	require.True(t, ok)
+	require.Equal(t, "abc", u.Error())
+	require.Nil(t, u.Unwrap())
+
+	attrs := u.Attrs()
+	require.Equal(t, Sensitive("panic", "abc"), attrs[0])
+	require.Equal(t, "stack", attrs[1].Key)
+	require.True(t, attrs[1].Sensitive)
+	require.Contains(t, attrs[1].Value, "Test_CatchPanic_1")
+}
+
+func Test_CatchPanic_2(t *testing.T) {
+	r := IntRealm{}
+	cause := r.New("efg")
+
+	f := func() (e error) {
+		defer CatchPanic(&e)
+		panic(cause)
+	}
+
+	e := f()
+	require.True(t, AllOrdered(e, ErrPanic, cause))
+	require.Equal(t, "Recovered from panic\n⤷ panic value\n⤷ efg\n", ErrorStack(e))
+
+	f = func() (e error) {
+		defer CatchPanic(&e)
+		return nil
+	}
+
+	require.Nil(t, f())
+}
+
+func Test_CatchPanic_3(t *testing.T) {
+	f := func() (e error) {
+		defer CatchPanic(&e)
+		panic("abc")
+	}
+
+	e := f()
+	require.Equal(t, "Recovered from panic\n⤷ abc\n", ErrorStackf(e, FormatAttrs(DefaultFormatter)))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	require.Nil(t, WriteProblem(rec, req, e))
+	require.NotContains(t, rec.Body.String(), "attrs")
+	require.NotContains(t, rec.Body.String(), "goroutine")
+}
+
+func Test_Group_1(t *testing.T) {
+	g := Group{}
+
+	g.Go(func() error { return nil })
+	g.Go(func() error { return nil })
+
+	require.Nil(t, g.Wait())
+	require.Nil(t, g.WaitAll())
+}
+
+func Test_Group_2(t *testing.T) {
+	r := IntRealm{}
+	abc := r.New("abc")
+
+	g, ctx := WithContext(context.Background())
+	started := make(chan struct{})
+
+	g.Go(func() error {
+		<-started
+		return abc
+	})
+
+	g.Go(func() error {
+		close(started)
+		<-ctx.Done()
+		panic(strings.Repeat("x", 3))
+	})
+
+	e := g.Wait()
+	require.True(t, errors.Is(e, abc))
+	require.True(t, errors.Is(context.Cause(ctx), abc))
+
+	all := g.WaitAll()
+	require.True(t, errors.Is(all, abc))
+	require.True(t, errors.Is(all, ErrPanic))
+	require.Equal(t, 2, all.(*MultiError).Len())
+}
+
+func Test_Group_3(t *testing.T) {
+	g, ctx := WithContext(context.Background())
+
+	g.Go(func() error { return nil })
+
+	require.Nil(t, g.Wait())
+	require.Equal(t, context.Canceled, ctx.Err())
+	require.Equal(t, context.Canceled, context.Cause(ctx))
+}
@@ -0,0 +1,254 @@
+package trackerr
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"reflect"
+)
+
+// HTTP annotates the tracked error with an HTTP status code and a public
+// facing title then returns it. If the title is empty the standard status
+// text is used.
+//
+//		var ErrUserNotFound = trackerr.Coded("USR-0001", "User not found").
+//			HTTP(http.StatusNotFound, "User not found")
+//
+// Like Describe, it should only be called during package initialisation
+// because the annotation is shared with all copies of the error.
+func (e *TrackedError) HTTP(status int, title string) *TrackedError {
+	if e.decl == nil {
+		e.decl = &declaration{}
+	}
+
+	if title == "" {
+		title = http.StatusText(status)
+	}
+
+	e.decl.status = status
+	e.decl.title = title
+	return e
+}
+
+// HTTPStatus returns the error's own HTTP status code or zero if it doesn't
+// have one. See ResolveStatus for resolving a whole error stack.
+func (e TrackedError) HTTPStatus() int {
+	if e.decl == nil {
+		return 0
+	}
+	return e.decl.status
+}
+
+// Title returns the error's own public facing title or an empty string if it
+// doesn't have one.
+func (e TrackedError) Title() string {
+	if e.decl == nil {
+		return ""
+	}
+	return e.decl.title
+}
+
+// ResolveStatus returns the HTTP status code and title of the most specific
+// tracked error in the stack annotated via HTTP. That is, the deepest one
+// which is closest to the root cause.
+//
+// If no errors are annotated then 500 Internal Server Error is returned.
+//
+//		var (
+//			ErrLoading  = trackerr.New("Failed to load").HTTP(500, "")
+//			ErrNotFound = trackerr.New("Not found").HTTP(404, "")
+//		)
+//
+//		status, title := trackerr.ResolveStatus(ErrLoading.CausedBy(ErrNotFound))
+//
+//		// status: 404
+//		// title: "Not Found"
+func ResolveStatus(e error) (int, string) {
+	if te := resolveHTTP(e); te != nil {
+		return te.decl.status, te.decl.title
+	}
+
+	status := http.StatusInternalServerError
+	return status, http.StatusText(status)
+}
+
+func resolveHTTP(e error) *TrackedError {
+	var deepest *TrackedError
+	maxDepth := -1
+
+	WalkStack(e, func(err error, depth int) bool {
+		if te, ok := asTracked(err); ok && te.HTTPStatus() != 0 && depth > maxDepth {
+			deepest, maxDepth = te, depth
+		}
+		return true
+	})
+
+	return deepest
+}
+
+// Problem is an RFC 7807 problem details object.
+//
+// Code is the stable code of the tracked error that determined the status,
+// or failing that the first tracked error in the stack with a code. Detail is
+// chosen in the same way from public messages, see Public. Attrs holds the
+// attributes in the stack, see CollectAttrs, whose values are strings,
+// booleans, or finite numbers. Attributes marked as sensitive and those with
+// any other value, such as errors, are left out because they could expose
+// internal details.
+type Problem struct {
+	Type     string         `json:"type"`
+	Title    string         `json:"title"`
+	Status   int            `json:"status"`
+	Detail   string         `json:"detail,omitempty"`
+	Instance string         `json:"instance,omitempty"`
+	Code     string         `json:"code,omitempty"`
+	Attrs    map[string]any `json:"attrs,omitempty"`
+}
+
+// NewProblem returns the Problem describing the error stack.
+//
+// Internal messages are not included because they're intended for engineers
+// and may contain details, such as file paths, that shouldn't be exposed.
+func NewProblem(e error) Problem {
+	p := Problem{Type: "about:blank"}
+	p.Status, p.Title = ResolveStatus(e)
+
+	te := resolveHTTP(e)
+
+	if te != nil && te.code != "" {
+		p.Code = te.code
+	} else {
+		p.Code = firstCode(e)
+	}
+
+	if te != nil && te.PublicMessage() != "" {
+		p.Detail = te.PublicMessage()
+	} else {
+		p.Detail = firstPublicMessage(e)
+	}
+
+	for _, a := range CollectAttrs(e) {
+		if a.Sensitive {
+			continue
+		}
+
+		v, ok := publicValue(a.Value)
+		if !ok {
+			continue
+		}
+
+		if p.Attrs == nil {
+			p.Attrs = map[string]any{}
+		}
+		p.Attrs[a.Key] = v
+	}
+
+	return p
+}
+
+// publicValue returns the attribute value as a plain string, boolean, or
+// number. False is returned for any other value, including errors and types
+// with custom JSON encodings, because they may not be safe to expose.
+func publicValue(v any) (any, bool) {
+	switch v.(type) {
+	case nil, error, json.Marshaler:
+		return nil, false
+	}
+
+	rv := reflect.ValueOf(v)
+
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String(), true
+
+	case reflect.Bool:
+		return rv.Bool(), true
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return rv.Uint(), true
+
+	case reflect.Float32, reflect.Float64:
+		if f := rv.Float(); !math.IsNaN(f) && !math.IsInf(f, 0) {
+			return f, true
+		}
+	}
+
+	return nil, false
+}
+
+func firstCode(e error) string {
+	code := ""
+
+	WalkStack(e, func(err error, _ int) bool {
+		if te, ok := asTracked(err); ok && te.code != "" {
+			code = te.code
+		}
+		return code == ""
+	})
+
+	return code
+}
+
+func firstPublicMessage(e error) string {
+	msg := ""
+
+	WalkStack(e, func(err error, _ int) bool {
+		msg = publicMessage(err)
+		return msg == ""
+	})
+
+	return msg
+}
+
+// WriteProblem writes the error stack as an RFC 7807 'application/problem+json'
+// response. The request's path is used as the problem's instance.
+//
+//		func handler(w http.ResponseWriter, r *http.Request) {
+//			if e := serve(w, r); e != nil {
+//				trackerr.WriteProblem(w, r, e)
+//			}
+//		}
+//
+//		```
+//		HTTP/1.1 404 Not Found
+//		Content-Type: application/problem+json
+//
+//		{
+//			"type": "about:blank",
+//			"title": "User not found",
+//			"status": 404,
+//			"instance": "/users/42",
+//			"code": "USR-0001"
+//		}
+//		```
+//
+// A response is always written. If the problem can't be marshalled then one
+// with only its type, title, and status is written instead and the
+// marshalling error is returned.
+func WriteProblem(w http.ResponseWriter, r *http.Request, e error) error {
+	p := NewProblem(e)
+
+	if r != nil && r.URL != nil {
+		p.Instance = r.URL.Path
+	}
+
+	body, marshalErr := json.Marshal(p)
+	if marshalErr != nil {
+		body, _ = json.Marshal(Problem{
+			Type:   p.Type,
+			Title:  p.Title,
+			Status: p.Status,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(p.Status)
+
+	if _, err := w.Write(body); err != nil {
+		return err
+	}
+	return marshalErr
+}
@@ -0,0 +1,135 @@
+package trackerr
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_HTTP_1(t *testing.T) {
+	r := IntRealm{}
+
+	a := r.New("a")
+	require.Equal(t, 0, a.HTTPStatus())
+	require.Equal(t, "", a.Title())
+
+	require.Same(t, a, a.HTTP(404, "Missing thing"))
+	require.Equal(t, 404, a.Because("b").(*TrackedError).HTTPStatus())
+	require.Equal(t, "Missing thing", a.Title())
+
+	b := r.New("b").HTTP(http.StatusConflict, "")
+	require.Equal(t, "Conflict", b.Title())
+}
+
+func Test_ResolveStatus_1(t *testing.T) {
+	r := IntRealm{}
+	loading := r.New("loading").HTTP(500, "")
+	notFound := r.New("not found").HTTP(404, "")
+	none := r.New("none")
+
+	status, title := ResolveStatus(loading.CausedBy(none.CausedBy(notFound)))
+	require.Equal(t, 404, status)
+	require.Equal(t, "Not Found", title)
+
+	status, _ = ResolveStatus(loading.CausedBy(none))
+	require.Equal(t, 500, status)
+
+	status, title = ResolveStatus(none.Because("a"))
+	require.Equal(t, 500, status)
+	require.Equal(t, "Internal Server Error", title)
+}
+
+func Test_WriteProblem_1(t *testing.T) {
+	r := IntRealm{}
+	loading := r.Coded("APP-1", "Failed to load").HTTP(500, "")
+	notFound := r.Coded("USR-1", "User not found").HTTP(404, "User not found")
+
+	e := loading.WithAttrs(KV("request", "abc")).CausedBy(
+		notFound.Because("No row in /var/db/users.sqlite",
+			KV("user", 42),
+			Sensitive("email", "bob@example.com"),
+		),
+	)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+
+	require.Nil(t, WriteProblem(rec, req, e))
+	require.Equal(t, 404, rec.Code)
+	require.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+
+	act := map[string]any{}
+	require.Nil(t, json.Unmarshal(rec.Body.Bytes(), &act))
+
+	exp := map[string]any{
+		"type":     "about:blank",
+		"title":    "User not found",
+		"status":   404.0,
+		"instance": "/users/42",
+		"code":     "USR-1",
+		"attrs": map[string]any{
+			"request": "abc",
+			"user":    42.0,
+		},
+	}
+
+	require.Equal(t, exp, act)
+}
+
+func Test_NewProblem_1(t *testing.T) {
+	r := IntRealm{}
+	coded := r.Coded("APP-1", "a").Public("Something went wrong")
+
+	act := NewProblem(r.New("b").CausedBy(coded))
+	exp := Problem{
+		Type:   "about:blank",
+		Title:  "Internal Server Error",
+		Status: 500,
+		Detail: "Something went wrong",
+		Code:   "APP-1",
+	}
+
+	require.Equal(t, exp, act)
+}
+
+type userID int
+
+func Test_NewProblem_2(t *testing.T) {
+	r := IntRealm{}
+	e := r.New("a").Because("b",
+		KV("cause", Untracked("SELECT * FROM users WHERE pw='x'")),
+		KV("ch", make(chan int)),
+		KV("nan", math.NaN()),
+		KV("list", []string{"x"}),
+		KV("nil", nil),
+		KV("id", userID(7)),
+		KV("ok", true),
+		KV("ratio", 0.5),
+		KV("name", "bob"),
+	)
+
+	exp := map[string]any{
+		"id":    int64(7),
+		"ok":    true,
+		"ratio": 0.5,
+		"name":  "bob",
+	}
+
+	require.Equal(t, exp, NewProblem(e).Attrs)
+}
+
+func Test_WriteProblem_2(t *testing.T) {
+	r := IntRealm{}
+	notFound := r.New("User not found").HTTP(404, "")
+
+	rec := httptest.NewRecorder()
+	e := notFound.Because("a", KV("ch", make(chan int)))
+
+	require.Nil(t, WriteProblem(rec, nil, e))
+	require.Equal(t, 404, rec.Code)
+	require.JSONEq(t, `{"type":"about:blank","title":"Not Found","status":404}`, rec.Body.String())
+}
@@ -0,0 +1,325 @@
+package trackerr
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// ErrUnmarshalling is returned when a serialised error stack could not be
+// decoded.
+var ErrUnmarshalling = New("Could not unmarshal error stack")
+
+// JSONStack is the stable JSON schema used to serialise error stacks.
+//
+//		{
+//			"message": "Failed to load data",
+//			"stack": [
+//				{
+//					"message": "Failed to load data",
+//					"tracked": true,
+//					"id": 4,
+//					"code": "DATA-0001",
+//					"attrs": {"user": 42},
+//					"site": "data.Load load.go:24"
+//				},
+//				{
+//					"message": "open data.csv",
+//					"tracked": false,
+//					"type": "*fs.PathError",
+//					"depth": 1
+//				},
+//				{
+//					"message": "no such file or directory",
+//					"tracked": false,
+//					"type": "syscall.Errno",
+//					"depth": 2
+//				}
+//			]
+//		}
+//
+// Message is the head error's full message, i.e. the result of Error, while
+// Stack contains a JSONNode for every error returned by SliceStack. Errors
+// that wrap multiple causes, such as MultiErrors, are kept as trees: each
+// node's Depth is its distance from the head and its causes are the nodes
+// that follow it with a Depth one greater.
+//
+// Fields may be added in future but existing fields will not be removed,
+// renamed, or change meaning.
+type JSONStack struct {
+	Message string     `json:"message"`
+	Stack   []JSONNode `json:"stack"`
+}
+
+// JSONNode is the JSON schema of a single error within a JSONStack.
+//
+// Message is the error's own message without its cause's message appended.
+// ID and Code are only present for tracked errors and only if they have them.
+// Attrs contains the error's own attributes; values that are errors are
+// replaced by their messages. Sensitive lists the keys of attributes marked
+// as sensitive so they remain so when unmarshalled. Public is the error's
+// public message, see Public, if it has one. Type is the Go type name of errors not from this
+// package. Site is the error's call site if one was recorded. Checkpoint is
+// only present, as true, for errors created via Checkpoint. Depth is the
+// number of errors between the node and the head, it's omitted for the head.
+// Join is only present, as true, for errors that do nothing but join their
+// causes, e.g. MultiErrors and those created by errors.Join.
+type JSONNode struct {
+	Message    string         `json:"message"`
+	Tracked    bool           `json:"tracked"`
+	ID         int            `json:"id,omitempty"`
+	Code       string         `json:"code,omitempty"`
+	Attrs      map[string]any `json:"attrs,omitempty"`
+	Sensitive  []string       `json:"sensitive,omitempty"`
+	Public     string         `json:"public,omitempty"`
+	Type       string         `json:"type,omitempty"`
+	Site       string         `json:"site,omitempty"`
+	Checkpoint bool           `json:"checkpoint,omitempty"`
+	Depth      int            `json:"depth,omitempty"`
+	Join       bool           `json:"join,omitempty"`
+}
+
+// MarshalJSON satisfies json.Marshaler by serialising the error stack as a
+// JSONStack.
+func (e TrackedError) MarshalJSON() ([]byte, error) {
+	return MarshalStack(&e)
+}
+
+// MarshalJSON satisfies json.Marshaler by serialising the error stack as a
+// JSONStack.
+func (e UntrackedError) MarshalJSON() ([]byte, error) {
+	return MarshalStack(&e)
+}
+
+// MarshalStack serialises any error, and the errors in its stack, as a
+// JSONStack.
+func MarshalStack(e error) ([]byte, error) {
+	return json.Marshal(NewJSONStack(e))
+}
+
+// NewJSONStack returns the JSONStack for the error e. Nil is returned if e is
+// nil.
+func NewJSONStack(e error) *JSONStack {
+	if e == nil {
+		return nil
+	}
+
+	js := &JSONStack{
+		Message: e.Error(),
+	}
+
+	WalkStack(e, func(err error, depth int) bool {
+		js.Stack = append(js.Stack, newJSONNode(err, depth))
+		return true
+	})
+
+	return js
+}
+
+func newJSONNode(e error, depth int) JSONNode {
+	n := JSONNode{
+		Depth: depth,
+		Join:  isJoin(e, causes(e)),
+	}
+
+	if te, ok := asTracked(e); ok {
+		e = te
+	}
+
+	switch v := e.(type) {
+	case *TrackedError:
+		n.Message = v.msg
+		n.Tracked = true
+		n.ID = v.id
+		n.Code = v.code
+
+	case *UntrackedError:
+		n.Message = v.msg
+		n.Checkpoint = v.checkpoint
+
+	default:
+		n.Message = ErrorWithoutCause(e)
+		n.Type = fmt.Sprintf("%T", e)
+	}
+
+	if attrs := ownAttrs(e); len(attrs) > 0 {
+		n.Attrs = make(map[string]any, len(attrs))
+
+		for _, a := range attrs {
+			if err, ok := a.Value.(error); ok {
+				n.Attrs[a.Key] = err.Error()
+			} else {
+				n.Attrs[a.Key] = a.Value
+			}
+
+			if a.Sensitive {
+				n.Sensitive = append(n.Sensitive, a.Key)
+			}
+		}
+	}
+
+	n.Public = publicMessage(e)
+
+	if cs, ok := e.(interface{ CallSite() (Frame, bool) }); ok {
+		if f, ok := cs.CallSite(); ok {
+			n.Site = f.String()
+		}
+	}
+
+	return n
+}
+
+// UnmarshalStack decodes a JSONStack, such as one produced by MarshalStack,
+// and rebuilds the error stack it describes.
+//
+// Nodes with a code are bound to the tracked error in this package's global
+// Realm with the same code so errors.Is works across process boundaries. This
+// requires both sides to share the same package of declared errors. All other
+// nodes become untracked errors. Messages and attributes are always taken
+// from the serialised form, attributes listed as sensitive remain so, and
+// untracked errors keep their public messages.
+//
+//		// Server
+//		data, _ := trackerr.MarshalStack(ErrNotFound.Because("No user with ID %d", id))
+//
+//		// Client
+//		e, _ := trackerr.UnmarshalStack(data)
+//		errors.Is(e, ErrNotFound) // true
+//
+// A nil error is returned if the JSONStack is null or has no nodes.
+func UnmarshalStack(data []byte) (error, error) {
+	return unmarshalStack(&globalRealm, data)
+}
+
+// UnmarshalStack is the same as the package scooped UnmarshalStack except
+// nodes are bound to tracked errors in the receiving Realm.
+func (r *IntRealm) UnmarshalStack(data []byte) (error, error) {
+	return unmarshalStack(r, data)
+}
+
+func unmarshalStack(r Realm, data []byte) (error, error) {
+	var js *JSONStack
+
+	if e := json.Unmarshal(data, &js); e != nil {
+		return nil, ErrUnmarshalling.CausedBy(e)
+	}
+
+	if js == nil {
+		return nil, nil
+	}
+
+	return js.rebuild(r), nil
+}
+
+func (js JSONStack) rebuild(r Realm) error {
+	if len(js.Stack) == 0 {
+		return nil
+	}
+
+	e, _ := js.rebuildNode(r, 0, js.depths())
+	return e
+}
+
+// depths returns the depth of each node such that every node, except the
+// head, is at most one deeper than the node before it. Stacks without depths,
+// i.e. ones serialised before Depth was added, are treated as linear chains.
+func (js JSONStack) depths() []int {
+	depths := make([]int, len(js.Stack))
+	linear := true
+
+	for _, n := range js.Stack {
+		if n.Depth != 0 {
+			linear = false
+		}
+	}
+
+	for i := 1; i < len(js.Stack); i++ {
+		switch d := js.Stack[i].Depth; {
+		case linear:
+			depths[i] = i
+		case d < 1:
+			depths[i] = 1
+		case d > depths[i-1]+1:
+			depths[i] = depths[i-1] + 1
+		default:
+			depths[i] = d
+		}
+	}
+
+	return depths
+}
+
+// rebuildNode rebuilds the error at index i along with its causes, i.e. the
+// nodes that follow it and are deeper. The index of the first node that isn't
+// part of the rebuilt error is also returned.
+func (js JSONStack) rebuildNode(r Realm, i int, depths []int) (error, int) {
+	n := js.Stack[i]
+
+	var cs []error
+	next := i + 1
+
+	for next < len(js.Stack) && depths[next] == depths[i]+1 {
+		var c error
+		c, next = js.rebuildNode(r, next, depths)
+		cs = append(cs, c)
+	}
+
+	if n.Join {
+		return Multi(cs...), next
+	}
+
+	var cause error
+	switch len(cs) {
+	case 0:
+	case 1:
+		cause = cs[0]
+	default:
+		cause = Multi(cs...)
+	}
+
+	attrs := n.attrs()
+
+	if te, ok := r.LookupCode(n.Code); ok && n.Code != "" {
+		cp := *te
+		cp.msg = n.Message
+		cp.attrs = attrs
+		cp.cause = cause
+		return &cp, next
+	}
+
+	return &UntrackedError{
+		msg:        n.Message,
+		attrs:      attrs,
+		cause:      cause,
+		checkpoint: n.Checkpoint,
+		public:     n.Public,
+	}, next
+}
+
+// attrs returns the node's attributes sorted by key. Those listed in
+// Sensitive are marked as sensitive.
+func (n JSONNode) attrs() []Attr {
+	if len(n.Attrs) == 0 {
+		return nil
+	}
+
+	sensitive := make(map[string]bool, len(n.Sensitive))
+	for _, k := range n.Sensitive {
+		sensitive[k] = true
+	}
+
+	attrs := make([]Attr, 0, len(n.Attrs))
+	for k, v := range n.Attrs {
+		attrs = append(attrs, Attr{
+			Key:       k,
+			Value:     v,
+			Sensitive: sensitive[k],
+		})
+	}
+
+	sort.Slice(attrs, func(i, j int) bool {
+		return attrs[i].Key < attrs[j].Key
+	})
+
+	return attrs
+}
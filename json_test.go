@@ -0,0 +1,172 @@
+package trackerr
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "Update golden files")
+
+func requireGolden(t *testing.T, name string, act []byte) {
+	t.Helper()
+
+	file := filepath.Join("testdata", name+".golden")
+
+	if *update {
+		require.Nil(t, os.WriteFile(file, act, 0644))
+	}
+
+	exp, e := os.ReadFile(file)
+	require.Nil(t, e)
+	require.Equal(t, string(exp), string(act))
+}
+
+func Test_MarshalStack_1(t *testing.T) {
+	r := IntRealm{}
+	abc := r.Coded("ABC-1", "abc").Public("Something went wrong")
+	efg := r.New("efg")
+
+	pathErr := &fs.PathError{Op: "open", Path: "data.csv", Err: fs.ErrNotExist}
+	e := abc.WithAttrs(KV("user", 42)).CausedBy(
+		efg.BecauseOf(pathErr, "hij", KV("cause", Untracked("klm")), Sensitive("email", "a@b.c")),
+	)
+	e = fmt.Errorf("nop: %w", e)
+
+	act, err := json.MarshalIndent(NewJSONStack(e), "", "\t")
+	require.Nil(t, err)
+
+	requireGolden(t, "marshal_stack_1", act)
+}
+
+func Test_MarshalStack_2(t *testing.T) {
+	act, e := MarshalStack(nil)
+	require.Nil(t, e)
+	require.Equal(t, "null", string(act))
+}
+
+func Test_MarshalJSON_1(t *testing.T) {
+	r := IntRealm{}
+	e := r.New("abc").Because("efg")
+
+	act, err := json.Marshal(e)
+	require.Nil(t, err)
+	requireGolden(t, "marshal_json_1", act)
+
+	act, err = json.Marshal(Untracked("abc"))
+	require.Nil(t, err)
+	requireGolden(t, "marshal_json_2", act)
+}
+
+func Test_UnmarshalStack_1(t *testing.T) {
+	r := IntRealm{}
+	abc := r.Coded("ABC-1", "abc")
+	efg := r.Coded("EFG-1", "efg")
+	hij := r.New("hij")
+
+	given := abc.WithAttrs(KV("user", 42), KV("name", "bob")).CausedBy(
+		efg.BecauseOf(hij.Because("klm"), "nop"),
+	)
+
+	data, e := MarshalStack(given)
+	require.Nil(t, e)
+
+	act, e := r.UnmarshalStack(data)
+	require.Nil(t, e)
+
+	require.True(t, AllOrdered(act, abc, efg))
+	require.False(t, Is(act, hij))
+	require.Equal(t, ErrorStack(given), ErrorStack(act))
+
+	head := act.(*TrackedError)
+	require.Equal(t, "ABC-1", head.Code())
+	require.Equal(t, []Attr{KV("name", "bob"), KV("user", 42.0)}, head.Attrs())
+}
+
+func Test_UnmarshalStack_2(t *testing.T) {
+	r := IntRealm{}
+	data := []byte(`{
+		"message": "abc",
+		"stack": [
+			{"message": "abc", "tracked": true, "code": "ABC-1"},
+			{"message": "efg", "tracked": false, "type": "*errors.errorString"}
+		]
+	}`)
+
+	act, e := r.UnmarshalStack(data)
+	require.Nil(t, e)
+
+	exp := &UntrackedError{
+		msg: "abc",
+		cause: &UntrackedError{
+			msg: "efg",
+		},
+	}
+	require.Equal(t, exp, act)
+}
+
+func Test_UnmarshalStack_3(t *testing.T) {
+	act, e := UnmarshalStack([]byte("null"))
+	require.Nil(t, e)
+	require.Nil(t, act)
+
+	_, e = UnmarshalStack([]byte("{"))
+	require.True(t, Is(e, ErrUnmarshalling))
+}
+
+func Test_UnmarshalStack_4(t *testing.T) {
+	r := IntRealm{}
+	a := r.Coded("A-1", "a")
+	b := r.Coded("B-1", "b")
+	c := r.Coded("C-1", "c")
+
+	given := c.CausedBy(Untracked("x")).(*TrackedError).CausedByAll(
+		a.Because("a1"),
+		errors.Join(b.Because("b1"), Untracked("y")),
+	)
+
+	data, e := MarshalStack(given)
+	require.Nil(t, e)
+
+	act, e := r.UnmarshalStack(data)
+	require.Nil(t, e)
+
+	require.Equal(t, ErrorStack(given), ErrorStack(act))
+	require.True(t, AllOrdered(act, c, a))
+	require.True(t, AllOrdered(act, c, b))
+	require.False(t, AllOrdered(act, a, b))
+
+	var m *MultiError
+	require.True(t, errors.As(act, &m))
+	require.Equal(t, 2, m.Len())
+}
+
+func Test_UnmarshalStack_5(t *testing.T) {
+	r := IntRealm{}
+	nf := r.Coded("NF-1", "nf").HTTP(404, "")
+
+	given := nf.CausedBy(
+		Untracked("no user", KV("user", 42), Sensitive("email", "a@b.c")).
+			Public("User not found"),
+	)
+
+	data, e := MarshalStack(given)
+	require.Nil(t, e)
+
+	act, e := r.UnmarshalStack(data)
+	require.Nil(t, e)
+
+	exp := []Attr{Sensitive("email", "a@b.c"), KV("user", 42.0)}
+	require.Equal(t, exp, CollectAttrs(act))
+
+	p := NewProblem(act)
+	require.Equal(t, map[string]any{"user": 42.0}, p.Attrs)
+	require.Equal(t, "User not found", p.Detail)
+}
@@ -0,0 +1,139 @@
+package trackerr
+
+import (
+	"errors"
+	"strings"
+)
+
+// MultiError represents an untracked error that aggregates multiple errors,
+// such as the failures collected while validating input.
+//
+// It's similar to the error returned by errors.Join but can count and filter
+// its errors by tracked error. ErrorStack and ErrorStackf render its errors as
+// a nested list.
+type MultiError struct {
+	errs []error
+}
+
+// Multi returns a new *MultiError aggregating all non-nil errs. Like
+// errors.Join, nil is returned if there are no non-nil errs.
+//
+//		e := trackerr.Multi(a, nil, b)
+//		e.(*trackerr.MultiError).Len() // 2
+//
+//		trackerr.Multi(nil, nil) // nil
+func Multi(errs ...error) error {
+	e := &MultiError{}
+
+	for _, err := range errs {
+		if err != nil {
+			e.errs = append(e.errs, err)
+		}
+	}
+
+	if len(e.errs) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Error satisfies the error interface by returning the messages of its errors
+// separated by linefeeds.
+func (e MultiError) Error() string {
+	msgs := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the aggregated errors.
+//
+// It is designed to work with errors.Is and errors.As exposed by the standard
+// errors package so errors.Is returns true if any aggregated error, or one of
+// their causes, matches.
+func (e MultiError) Unwrap() []error {
+	return e.errs
+}
+
+// Errors returns the aggregated errors.
+func (e MultiError) Errors() []error {
+	return e.errs
+}
+
+// Len returns the number of aggregated errors.
+func (e MultiError) Len() int {
+	return len(e.errs)
+}
+
+// Count returns the number of aggregated errors for which errors.Is returns
+// true when given the target.
+func (e MultiError) Count(target error) int {
+	return len(e.Filter(target))
+}
+
+// Filter returns the aggregated errors for which errors.Is returns true when
+// given the target.
+func (e MultiError) Filter(target error) []error {
+	var result []error
+
+	for _, err := range e.errs {
+		if errors.Is(err, target) {
+			result = append(result, err)
+		}
+	}
+
+	return result
+}
+
+// CausedByAll returns a copy of the receiving error with a MultiError, that
+// aggregates all non-nil errs, as its cause. Nil is returned if there are no
+// non-nil errs so the usual validation pattern only fails when something did.
+//
+//		var ErrValidation = trackerr.New("Validation failed")
+//
+//		e := ErrValidation.CausedByAll(
+//			ErrMissing.Because("Name is required"),
+//			ErrInvalid.Because("Age must be positive"),
+//			ErrMissing.Because("Email is required"),
+//		)
+//
+//		```
+//		Validation failed
+//		├ ⤷ Name is required
+//		├ ⤷ Age must be positive
+//		└ ⤷ Email is required
+//		```
+//
+//		var m *trackerr.MultiError
+//		errors.As(e, &m)
+//		m.Count(ErrMissing) // 2
+//
+//		ErrValidation.CausedByAll(nil, nil) // nil
+func (e TrackedError) CausedByAll(errs ...error) error {
+	cause := Multi(errs...)
+	if cause == nil {
+		return nil
+	}
+
+	e.pcs = capture(e.realm, 1)
+	e.cause = cause
+	return &e
+}
+
+// CausedByAll returns a copy of the receiving error with a MultiError, that
+// aggregates all non-nil errs, as its cause. Nil is returned if there are no
+// non-nil errs.
+//
+// See TrackedError.CausedByAll for an example.
+func (e UntrackedError) CausedByAll(errs ...error) error {
+	cause := Multi(errs...)
+	if cause == nil {
+		return nil
+	}
+
+	e.pcs = capture(nil, 1)
+	e.cause = cause
+	return &e
+}
@@ -0,0 +1,109 @@
+package trackerr
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MultiError_1(t *testing.T) {
+	a := Untracked("a")
+	b := Untracked("b")
+
+	e := Multi(a, nil, b).(*MultiError)
+
+	require.Equal(t, 2, e.Len())
+	require.Equal(t, []error{a, b}, e.Errors())
+	require.Equal(t, "a\nb", e.Error())
+}
+
+func Test_MultiError_2(t *testing.T) {
+	r := IntRealm{}
+	missing := r.New("missing")
+	invalid := r.New("invalid")
+	other := r.New("other")
+
+	e := Multi(
+		missing.Because("a"),
+		invalid.Because("b"),
+		Untracked("c").CausedBy(missing),
+	).(*MultiError)
+
+	require.True(t, errors.Is(e, missing))
+	require.True(t, errors.Is(e, invalid))
+	require.False(t, errors.Is(e, other))
+
+	require.Equal(t, 2, e.Count(missing))
+	require.Equal(t, 1, e.Count(invalid))
+	require.Equal(t, 0, e.Count(other))
+
+	require.Equal(t, []error{e.errs[1]}, e.Filter(invalid))
+	require.Nil(t, e.Filter(other))
+}
+
+func Test_CausedByAll_1(t *testing.T) {
+	r := IntRealm{}
+	validation := r.New("Validation failed")
+	missing := r.New("Missing")
+	invalid := r.New("Invalid")
+
+	e := validation.CausedByAll(
+		missing.Because("Name is required"),
+		invalid.Because("Age must be positive"),
+		nil,
+		missing.Because("Email is required"),
+	)
+
+	require.True(t, AllOrdered(e, validation, missing))
+	require.True(t, AllOrdered(e, validation, invalid))
+
+	var m *MultiError
+	require.True(t, errors.As(e, &m))
+	require.Equal(t, 3, m.Len())
+	require.Equal(t, 2, m.Count(missing))
+
+	expLines := []string{
+		"Validation failed",
+		"├ ⤷ Missing",
+		"│ ⤷ Name is required",
+		"├ ⤷ Invalid",
+		"│ ⤷ Age must be positive",
+		"└ ⤷ Missing",
+		"  ⤷ Email is required",
+		"",
+	}
+	exp := strings.Join(expLines, "\n")
+
+	require.Equal(t, exp, ErrorStack(e))
+}
+
+func Test_CausedByAll_2(t *testing.T) {
+	e := Untracked("a").CausedByAll(Untracked("b"))
+
+	expLines := []string{
+		"a",
+		"└ ⤷ b",
+		"",
+	}
+	exp := strings.Join(expLines, "\n")
+
+	require.Equal(t, exp, ErrorStack(e))
+}
+
+func Test_Multi_1(t *testing.T) {
+	require.Nil(t, Multi())
+	require.Nil(t, Multi(nil, nil))
+}
+
+func Test_CausedByAll_3(t *testing.T) {
+	validate := func(errs ...error) error {
+		return New("Validation failed").CausedByAll(errs...)
+	}
+
+	require.Nil(t, validate())
+	require.Nil(t, validate(nil, nil))
+	require.Nil(t, Untracked("a").CausedByAll(nil))
+	require.NotNil(t, validate(nil, Untracked("b")))
+}
@@ -0,0 +1,226 @@
+package trackerr
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ParsedFrame is a single error recovered from the text of an error stack by
+// ParseStack.
+//
+// Level is the number of branches the error is nested within, zero unless the
+// stack contained errors with multiple causes.
+type ParsedFrame struct {
+	Message    string            `json:"message"`
+	Code       string            `json:"code,omitempty"`
+	Attrs      map[string]string `json:"attrs,omitempty"`
+	Site       string            `json:"site,omitempty"`
+	Trace      []string          `json:"trace,omitempty"`
+	Checkpoint bool              `json:"checkpoint,omitempty"`
+	Level      int               `json:"level,omitempty"`
+}
+
+var (
+	parseHeader = regexp.MustCompile(`^\[[^\]]*\]$`)
+	parseSite   = regexp.MustCompile(`(?s)^(.*) @ (\S+ \S+:\d+)$`)
+	parseAttrs  = regexp.MustCompile(`(?s)^(.*) \{([^{}]*)\}$`)
+	parseCode   = regexp.MustCompile(`(?s)^\[([^\]\s]+)\] (.*)$`)
+)
+
+// ParseStack recovers the structure of an error stack from its text, such as
+// that printed by ErrorStack, Debug, or the '%+v' verb. It's intended for
+// reading error stacks pasted into bug reports and support tickets.
+//
+//		frames := trackerr.ParseStack(`[DEBUG ERROR]
+//		  [APP-0001] Workflow error {user=42} @ main.run main.go:24
+//		⤷ Failed to read data`)
+//
+//		// frames: [
+//		// 	{Message: "Workflow error", Code: "APP-0001", Attrs: {user: 42}, Site: "main.run main.go:24"},
+//		// 	{Message: "Failed to read data"},
+//		// ]
+//
+// Headers printed by Debug are skipped. These are DefaultDebugHeader, any of
+// the headers passed, e.g. one configured via SetDebugHeader, and any other
+// bracketed line followed by an indented one. Prefixes common to every line,
+// such as log timestamps, are removed.
+//
+// Codes, attributes, call sites, stack traces, and checkpoints are separated
+// from messages when formatted as FormatCode, FormatAttrs, FormatCallSite,
+// FormatStackTrace, and FormatCheckpoint would. The text alone can't say
+// which formatters were used so this is always attempted. Messages that
+// happen to look like them, such as 'map {a=1}' or 'x @ y z:1', will be split
+// too. Lines that don't start a new error are treated as a continuation of
+// the previous error's message.
+func ParseStack(s string, headers ...string) []ParsedFrame {
+	lines := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	prefix := stackPrefix(lines)
+
+	for i := range lines {
+		lines[i] = strings.TrimPrefix(lines[i], prefix)
+	}
+
+	var frames []ParsedFrame
+	var msgs []string
+	afterHeader := false
+
+	for i, l := range lines {
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+
+		if len(frames) == 0 && !afterHeader && isHeader(l, nextLine(lines[i+1:]), headers) {
+			afterHeader = true
+			continue
+		}
+
+		if afterHeader {
+			l = strings.TrimPrefix(l, "  ")
+			afterHeader = false
+		}
+
+		level, branch, rest := splitGuides(l)
+		marked := strings.HasPrefix(rest, "⤷ ")
+		rest = strings.TrimPrefix(rest, "⤷ ")
+
+		switch {
+		case len(frames) == 0 || marked || branch:
+			frames = append(frames, ParsedFrame{Level: level})
+			msgs = append(msgs, rest)
+
+		case strings.HasPrefix(strings.TrimSpace(rest), "at "):
+			f := &frames[len(frames)-1]
+			f.Trace = append(f.Trace, strings.TrimPrefix(strings.TrimSpace(rest), "at "))
+
+		default:
+			msgs[len(msgs)-1] += "\n" + rest
+		}
+	}
+
+	for i := range frames {
+		parseMessage(&frames[i], msgs[i])
+	}
+
+	return frames
+}
+
+// isHeader returns true if the line is a header printed by Debug. That is,
+// the default debug header, one of the headers passed, or any other bracketed
+// text if the next line is indented as Debug indents the error stack.
+func isHeader(l, next string, headers []string) bool {
+	l = strings.TrimSuffix(strings.TrimSpace(l), " nil error")
+
+	if l == DefaultDebugHeader {
+		return true
+	}
+
+	for _, h := range headers {
+		if h != "" && l == h {
+			return true
+		}
+	}
+
+	return parseHeader.MatchString(l) &&
+		strings.HasPrefix(next, "  ") &&
+		!strings.HasPrefix(strings.TrimSpace(next), "at ")
+}
+
+// nextLine returns the first line that isn't blank.
+func nextLine(lines []string) string {
+	for _, l := range lines {
+		if strings.TrimSpace(l) != "" {
+			return l
+		}
+	}
+	return ""
+}
+
+// stackPrefix returns the text preceding the first '⤷' marker, minus any
+// branch guides, if it prefixes every line that isn't blank. Otherwise the
+// text is part of the stack, e.g. the indent of a branch, and an empty string
+// is returned.
+func stackPrefix(lines []string) string {
+	for _, l := range lines {
+		i := strings.Index(l, "⤷ ")
+		if i < 0 {
+			continue
+		}
+
+		p := l[:i]
+		for _, g := range []string{"├ ", "└ ", "│ "} {
+			if j := strings.Index(p, g); j >= 0 {
+				p = p[:j]
+			}
+		}
+
+		for _, other := range lines {
+			if strings.TrimSpace(other) != "" && !strings.HasPrefix(other, p) {
+				return ""
+			}
+		}
+		return p
+	}
+
+	return ""
+}
+
+// splitGuides removes the branch guides drawn by ErrorStack from the start of
+// the line returning the number removed and whether the line starts a branch.
+func splitGuides(l string) (int, bool, string) {
+	level := 0
+
+	for {
+		if rest, ok := strings.CutPrefix(l, "├ "); ok {
+			return level + 1, true, rest
+		}
+
+		if rest, ok := strings.CutPrefix(l, "└ "); ok {
+			return level + 1, true, rest
+		}
+
+		if rest, ok := strings.CutPrefix(l, "│ "); ok {
+			l, level = rest, level+1
+			continue
+		}
+
+		// Stack trace lines are indented too.
+		if rest, ok := strings.CutPrefix(l, "  "); ok && !strings.HasPrefix(strings.TrimSpace(rest), "at ") {
+			l, level = rest, level+1
+			continue
+		}
+
+		return level, false, l
+	}
+}
+
+func parseMessage(f *ParsedFrame, msg string) {
+	if m := parseSite.FindStringSubmatch(msg); m != nil {
+		msg, f.Site = m[1], m[2]
+	}
+
+	if m := parseAttrs.FindStringSubmatch(msg); m != nil {
+		msg, f.Attrs = m[1], parseAttrList(m[2])
+	}
+
+	if m := parseCode.FindStringSubmatch(msg); m != nil {
+		f.Code, msg = m[1], m[2]
+	}
+
+	if strings.HasPrefix(msg, "*** ") && strings.HasSuffix(msg, " ***") && len(msg) >= 8 {
+		msg = msg[4 : len(msg)-4]
+		f.Checkpoint = true
+	}
+
+	f.Message = msg
+}
+
+func parseAttrList(s string) map[string]string {
+	attrs := map[string]string{}
+
+	for _, kv := range strings.Split(s, ", ") {
+		k, v, _ := strings.Cut(kv, "=")
+		attrs[k] = v
+	}
+
+	return attrs
+}
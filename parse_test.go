@@ -0,0 +1,142 @@
+package trackerr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseStack_1(t *testing.T) {
+	r := IntRealm{}
+	e := r.New("a").CausedBy(Untracked("b").CausedBy(Untracked("c")))
+
+	exp := []ParsedFrame{
+		{Message: "a"},
+		{Message: "b"},
+		{Message: "c"},
+	}
+
+	require.Equal(t, exp, ParseStack(ErrorStack(e)))
+	require.Nil(t, ParseStack(""))
+}
+
+func Test_ParseStack_2(t *testing.T) {
+	s := "[DEBUG ERROR]\n" +
+		"  [APP-1] Workflow error {user=42, op=read} @ main.run main.go:24\n" +
+		"⤷ *** Data layer *** @ data.Load load.go:12\n" +
+		"⤷ Failed to read data\n" +
+		"    at data.read read.go:8\n" +
+		"    at data.Load load.go:14\n" +
+		"⤷ Line one\r\n" +
+		"line two {path=x.csv}\n"
+
+	exp := []ParsedFrame{
+		{
+			Message: "Workflow error",
+			Code:    "APP-1",
+			Attrs:   map[string]string{"user": "42", "op": "read"},
+			Site:    "main.run main.go:24",
+		},
+		{
+			Message:    "Data layer",
+			Site:       "data.Load load.go:12",
+			Checkpoint: true,
+		},
+		{
+			Message: "Failed to read data",
+			Trace:   []string{"data.read read.go:8", "data.Load load.go:14"},
+		},
+		{
+			Message: "Line one\nline two",
+			Attrs:   map[string]string{"path": "x.csv"},
+		},
+	}
+
+	require.Equal(t, exp, ParseStack(s))
+	require.Nil(t, ParseStack("[DEBUG ERROR] nil error"))
+}
+
+func Test_ParseStack_3(t *testing.T) {
+	r := IntRealm{}
+	e := Wrap(
+		r.Coded("APP-1", "Validation failed").CausedByAll(
+			Untracked("Name is required\nsecond line"),
+			Wrap(Untracked("Must be positive"), "Age is invalid"),
+		),
+		"Request failed",
+	)
+
+	exp := []ParsedFrame{
+		{Message: "Request failed"},
+		{Message: "Validation failed", Code: "APP-1"},
+		{Message: "Name is required\nsecond line", Level: 1},
+		{Message: "Age is invalid", Level: 1},
+		{Message: "Must be positive", Level: 1},
+	}
+
+	require.Equal(t, exp, ParseStack(ErrorStackf(e, FormatCode(DefaultFormatter))))
+}
+
+func Test_ParseStack_4(t *testing.T) {
+	s := "2024/01/02 15:04:05 [DEBUG ERROR]\n" +
+		"2024/01/02 15:04:05   Workflow error\n" +
+		"2024/01/02 15:04:05 ⤷ Failed to read data\n" +
+		"2024/01/02 15:04:05 ⤷ [FS-1] File not found\n"
+
+	exp := []ParsedFrame{
+		{Message: "Workflow error"},
+		{Message: "Failed to read data"},
+		{Message: "File not found", Code: "FS-1"},
+	}
+
+	require.Equal(t, exp, ParseStack(s))
+}
+
+func Test_ParseStack_5(t *testing.T) {
+	exp := []ParsedFrame{
+		{Message: "[REDACTED]"},
+		{Message: "[REDACTED]"},
+	}
+	require.Equal(t, exp, ParseStack("[REDACTED]\n⤷ [REDACTED]\n"))
+
+	r := IntRealm{}
+	e := r.New("a").CausedBy(Untracked("b"))
+	require.Len(t, ParseStack(ErrorStackf(e, FormatRedacted(DefaultFormatter))), 2)
+
+	exp = []ParsedFrame{
+		{Message: "a"},
+		{Message: "b"},
+	}
+	require.Equal(t, exp, ParseStack("[CUSTOM]\n  a\n⤷ b\n"))
+	require.Nil(t, ParseStack("[DEBUG ERROR] nil error\n"))
+
+	require.Nil(t, ParseStack("[MINE] nil error\n", "[MINE]"))
+}
+
+func Test_ParseStack_6(t *testing.T) {
+	e := Multi(
+		errors.New("a"),
+		Untracked("b").CausedBy(errors.New("c")),
+	)
+
+	exp := []ParsedFrame{
+		{Message: "a", Level: 1},
+		{Message: "b", Level: 1},
+		{Message: "c", Level: 1},
+	}
+
+	require.Equal(t, exp, ParseStack(ErrorStack(e)))
+}
+
+func Test_ParseStack_7(t *testing.T) {
+	_, reset := withDebugSink()
+	defer reset()
+
+	SetDebugHeader("[MY ERRORS]")
+
+	exp := []ParsedFrame{
+		{Message: "[MY ERRORS] nil error"},
+	}
+	require.Equal(t, exp, ParseStack("[MY ERRORS] nil error\n"))
+}
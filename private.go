@@ -2,6 +2,8 @@ package trackerr
 
 import (
 	"fmt"
+	"runtime"
+	"strings"
 )
 
 func fmtMsg(msg string, args ...any) string {
@@ -9,14 +11,69 @@ func fmtMsg(msg string, args ...any) string {
 }
 
 func because(msg string, args ...any) *UntrackedError {
+	args, attrs := splitArgs(args)
 	return &UntrackedError{
-		msg: fmtMsg(msg, args...),
+		msg:   fmtMsg(msg, args...),
+		attrs: attrs,
 	}
 }
 
 func causedBy(cause error, msg string, args ...any) *UntrackedError {
-	return &UntrackedError{
-		msg:   fmtMsg(msg, args...),
-		cause: cause,
+	e := because(msg, args...)
+	e.cause = cause
+	return e
+}
+
+// splitArgs separates Attrs from the arguments intended for formatting.
+func splitArgs(args []any) ([]any, []Attr) {
+	var attrs []Attr
+
+	for _, a := range args {
+		if attr, ok := a.(Attr); ok {
+			attrs = append(attrs, attr)
+		}
+	}
+
+	if attrs == nil {
+		return args, nil
+	}
+
+	fmtArgs := make([]any, 0, len(args)-len(attrs))
+	for _, a := range args {
+		if _, ok := a.(Attr); !ok {
+			fmtArgs = append(fmtArgs, a)
+		}
+	}
+
+	return fmtArgs, attrs
+}
+
+// callerPkg returns the import path of the package containing the function
+// skip frames up the call stack.
+func callerPkg(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip)
+	if !ok {
+		return ""
+	}
+
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return ""
+	}
+
+	return pkgOfFunc(fn.Name())
+}
+
+// pkgOfFunc returns the package import path from a fully qualified function
+// name such as 'github.com/user/repo/pkg.(*Type).Method'.
+//
+// The runtime escapes dots in the last path element so they are unescaped
+// before returning.
+func pkgOfFunc(name string) string {
+	slash := strings.LastIndex(name, "/") + 1
+
+	if dot := strings.Index(name[slash:], "."); dot >= 0 {
+		name = name[:slash+dot]
 	}
+	return strings.ReplaceAll(name, "%2e", ".")
 }
@@ -1,5 +1,9 @@
 package trackerr
 
+import (
+	"sync"
+)
+
 var (
 	globalRealm       IntRealm
 	globalInitialised bool
@@ -27,6 +31,12 @@ func checkInitState() {
 	}
 }
 
+func checkCode(code string) {
+	if code == "" {
+		panic(Untracked("Tracked error codes must not be empty."))
+	}
+}
+
 // Realm represents a space where each trackable error (stack trace node)
 // has its own unique ID.
 //
@@ -48,6 +58,18 @@ type Realm interface {
 
 	// Track returns a new tracked error, that is, one with a tracking ID.
 	Track(msg string, args ...any) *TrackedError
+
+	// Coded returns a new tracked error with a stable human assigned code.
+	Coded(code, msg string, args ...any) *TrackedError
+
+	// Lookup returns the tracked error with the ID id.
+	Lookup(id int) (*TrackedError, bool)
+
+	// LookupCode returns the tracked error with the code.
+	LookupCode(code string) (*TrackedError, bool)
+
+	// Errors returns all errors tracked by the Realm in declaration order.
+	Errors() []*TrackedError
 }
 
 // IntRealm is a Realm that uses a simple incrementing integer field as the
@@ -61,32 +83,124 @@ type Realm interface {
 // real world use case. However, Realms were conceived for such an event
 // and for those who really hate the idea of relying on a singleton they have
 // no control over.
+//
+// Every tracked error an IntRealm creates is kept in its registry so it can be
+// looked up by ID or code later. This means an IntRealm's memory use grows
+// with each error tracked.
+//
+// IntRealm is safe for concurrent use by multiple goroutines but must not be
+// copied after first use.
 type IntRealm struct {
-	idPool *int
+	capture int32
+
+	mu     sync.Mutex
+	idPool int
+	errs   []*TrackedError
+	codes  map[string]*TrackedError
 }
 
 // New is an alias for Track.
 func (r *IntRealm) New(msg string, args ...any) *TrackedError {
-	return Track(msg, args...)
+	return r.track("", msg, args...)
 }
 
-// Track returns a new tracked error.
+// Track returns a new tracked error belonging to the receiving Realm.
 //
 // Calls to HasTracked, IsTracked, and IsTrackerr will all return true when
 // the error is passed to them.
+//
+// Unlike the package scooped Track function, calling Initialised does not
+// prevent Realms from creating new tracked errors. The IDs of errors created
+// here are only unique within the receiving Realm so errors.Is will never
+// match them against errors from another Realm.
 func (r *IntRealm) Track(msg string, args ...any) *TrackedError {
-	return &TrackedError{
-		id:  r.newID(),
-		msg: fmtMsg(msg, args...),
+	return r.track("", msg, args...)
+}
+
+// Coded returns a new tracked error with a stable code, such as "DB-0042".
+//
+// Unlike IDs, which depend on the order in which errors are created, codes are
+// chosen by humans so remain the same between builds. This makes them suitable
+// for logging, alerting, and documenting.
+//
+// Coded panics if the code is empty or has already been used within the
+// receiving Realm.
+func (r *IntRealm) Coded(code, msg string, args ...any) *TrackedError {
+	checkCode(code)
+	return r.track(code, msg, args...)
+}
+
+// Lookup returns the tracked error with the ID id.
+func (r *IntRealm) Lookup(id int) (*TrackedError, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if id < 1 || id > len(r.errs) {
+		return nil, false
 	}
+	return r.errs[id-1], true
+}
+
+// LookupCode returns the tracked error with the code.
+func (r *IntRealm) LookupCode(code string) (*TrackedError, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := r.codes[code]
+	return e, ok
 }
 
-func (r *IntRealm) newID() int {
-	if r.idPool == nil {
-		n := 0
-		r.idPool = &n
+// Errors returns all errors tracked by the receiving Realm in the order they
+// were created, i.e. declaration order.
+func (r *IntRealm) Errors() []*TrackedError {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	errs := make([]*TrackedError, len(r.errs))
+	copy(errs, r.errs)
+	return errs
+}
+
+// track must be called directly by the exported functions that create tracked
+// errors so the declaring package can be identified from the call stack.
+func (r *IntRealm) track(code, msg string, args ...any) *TrackedError {
+	return r.register(code, fmtMsg(msg, args...), msg, callerPkg(3))
+}
+
+// template is the same as track except the message is left unformatted. It
+// must also be called directly by exported functions.
+func (r *IntRealm) template(code, tmpl string) *TrackedError {
+	return r.register(code, tmpl, tmpl, callerPkg(3))
+}
+
+func (r *IntRealm) register(code, msg, tmpl, pkg string) *TrackedError {
+	e := &TrackedError{
+		realm: r,
+		code:  code,
+		msg:   msg,
+		decl: &declaration{
+			pkg:  pkg,
+			tmpl: tmpl,
+		},
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if code != "" {
+		if _, ok := r.codes[code]; ok {
+			panic(Untracked("Tracked error code '%s' is already in use.", code))
+		}
+
+		if r.codes == nil {
+			r.codes = map[string]*TrackedError{}
+		}
+		r.codes[code] = e
 	}
 
-	(*r.idPool)++
-	return *r.idPool
+	r.idPool++
+	e.id = r.idPool
+	r.errs = append(r.errs, e)
+
+	return e
 }
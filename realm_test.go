@@ -1,6 +1,8 @@
 package trackerr
 
 import (
+	"errors"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -15,8 +17,13 @@ func Test_IntRealm_1(t *testing.T) {
 
 	act := r.Track("abc%d%d%d", 1, 2, 3)
 	exp := &TrackedError{
-		id:  1,
-		msg: "abc123",
+		realm: &r,
+		id:    1,
+		msg:   "abc123",
+		decl: &declaration{
+			pkg:  "github.com/PaulioRandall/go-trackerr",
+			tmpl: "abc%d%d%d",
+		},
 	}
 
 	require.Equal(t, exp, act)
@@ -29,9 +36,134 @@ func Test_IntRealm_2(t *testing.T) {
 	act := r.Track("efg%d%d%d", 4, 5, 6)
 
 	exp := &TrackedError{
-		id:  2,
-		msg: "efg456",
+		realm: &r,
+		id:    2,
+		msg:   "efg456",
+		decl: &declaration{
+			pkg:  "github.com/PaulioRandall/go-trackerr",
+			tmpl: "efg%d%d%d",
+		},
 	}
 
 	require.Equal(t, exp, act)
 }
+
+func Test_IntRealm_3(t *testing.T) {
+	const goroutines = 64
+	const perGoroutine = 256
+
+	r := IntRealm{}
+	ids := make(chan int, goroutines*perGoroutine)
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perGoroutine; j++ {
+				ids <- r.Track("abc").id
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(ids)
+
+	seen := map[int]bool{}
+	for id := range ids {
+		require.False(t, seen[id], "Duplicate ID %d", id)
+		seen[id] = true
+	}
+
+	require.Len(t, seen, goroutines*perGoroutine)
+}
+
+func Test_IntRealm_4(t *testing.T) {
+	defer func() {
+		globalInitialised = false
+	}()
+
+	Initialised()
+
+	r := IntRealm{}
+	e := r.New("abc")
+
+	require.Equal(t, Realm(&r), e.Realm())
+	require.Equal(t, 1, e.id)
+}
+
+func Test_IntRealm_5(t *testing.T) {
+	r1 := IntRealm{}
+	r2 := IntRealm{}
+
+	a := r1.Track("abc")
+	b := r2.Track("abc")
+
+	require.Equal(t, a.id, b.id)
+	require.False(t, errors.Is(a, b))
+	require.False(t, errors.Is(a.Because("efg"), b))
+	require.True(t, errors.Is(a.Because("efg"), a))
+}
+
+func Test_IntRealm_6(t *testing.T) {
+	r := IntRealm{}
+
+	act := r.Coded("ABC-1", "abc%d", 1)
+	exp := &TrackedError{
+		realm: &r,
+		id:    1,
+		code:  "ABC-1",
+		msg:   "abc1",
+		decl: &declaration{
+			pkg:  "github.com/PaulioRandall/go-trackerr",
+			tmpl: "abc%d",
+		},
+	}
+
+	require.Equal(t, exp, act)
+	require.Equal(t, "ABC-1", act.Code())
+}
+
+func Test_IntRealm_7(t *testing.T) {
+	r := IntRealm{}
+	_ = r.Coded("ABC-1", "abc")
+
+	require.Panics(t, func() {
+		r.Coded("ABC-1", "efg")
+	})
+
+	require.Panics(t, func() {
+		r.Coded("", "efg")
+	})
+}
+
+func Test_IntRealm_8(t *testing.T) {
+	r := IntRealm{}
+
+	abc := r.Track("abc")
+	efg := r.Coded("EFG-1", "efg")
+	hij := r.New("hij%d", 1)
+
+	require.Equal(t, []*TrackedError{abc, efg, hij}, r.Errors())
+
+	act, ok := r.Lookup(2)
+	require.True(t, ok)
+	require.Same(t, efg, act)
+
+	_, ok = r.Lookup(0)
+	require.False(t, ok)
+
+	_, ok = r.Lookup(4)
+	require.False(t, ok)
+
+	act, ok = r.LookupCode("EFG-1")
+	require.True(t, ok)
+	require.Same(t, efg, act)
+
+	_, ok = r.LookupCode("ABC-1")
+	require.False(t, ok)
+
+	require.Equal(t, "github.com/PaulioRandall/go-trackerr", hij.Package())
+	require.Equal(t, "hij%d", hij.Template())
+	require.Equal(t, "hij1", hij.Error())
+}
@@ -0,0 +1,123 @@
+package trackerr
+
+import (
+	"strings"
+)
+
+// Redacted replaces the messages of errors without a public message when
+// formatted via FormatRedacted.
+const Redacted = "[REDACTED]"
+
+// Public attaches a message, safe to show users, to the tracked error then
+// returns it. The error's normal message is considered internal and may
+// contain details such as file paths or queries.
+//
+//		var ErrLoadingUser = trackerr.New("Failed to query users table").
+//			Public("Could not load your profile")
+//
+// Like Describe, it should only be called during package initialisation
+// because the public message is shared with all copies of the error.
+func (e *TrackedError) Public(msg string) *TrackedError {
+	if e.decl == nil {
+		e.decl = &declaration{}
+	}
+	e.decl.public = msg
+	return e
+}
+
+// PublicMessage returns the error's public message or an empty string if it
+// doesn't have one.
+func (e TrackedError) PublicMessage() string {
+	if e.decl == nil {
+		return ""
+	}
+	return e.decl.public
+}
+
+// Public returns a copy of the error with a message, safe to show users,
+// attached. The error's normal message is considered internal.
+//
+//		e := trackerr.Untracked("Row %d missing from %s", id, table).
+//			Public("Item not found")
+func (e UntrackedError) Public(msg string) *UntrackedError {
+	e.public = msg
+	return &e
+}
+
+// PublicMessage returns the error's public message or an empty string if it
+// doesn't have one.
+func (e UntrackedError) PublicMessage() string {
+	return e.public
+}
+
+func publicMessage(e error) string {
+	if p, ok := e.(interface{ PublicMessage() string }); ok {
+		return p.PublicMessage()
+	}
+	return ""
+}
+
+// FormatRedacted returns an ErrorFormatter that replaces the message of each
+// error with its public message, or Redacted if it doesn't have one, before
+// passing it on to f.
+//
+// Decorators passed to FormatRedacted, i.e. those within it, still add their
+// details after the message is replaced. FormatCode and FormatAttrs are safe
+// to use because codes are stable identifiers and sensitive attributes are
+// never written. FormatCallSite and FormatStackTrace expose source locations
+// so shouldn't be used when the output is intended for users.
+//
+//		f := trackerr.FormatRedacted(trackerr.FormatAttrs(trackerr.DefaultFormatter))
+//		s := trackerr.ErrorStackf(e, f)
+//
+//		// Could not load your profile
+//		// ⤷ [REDACTED] {user=42}
+func FormatRedacted(f ErrorFormatter) ErrorFormatter {
+	return func(errMsg string, e error, isFirst bool) string {
+		if errMsg = publicMessage(e); errMsg == "" {
+			errMsg = Redacted
+		}
+
+		return applyFormatter(f, errMsg, e, isFirst)
+	}
+}
+
+// RedactedStack returns a string containing only the public messages within
+// the error stack. Errors without public messages are omitted entirely so
+// it's safe to show users.
+//
+//		e := ErrLoadingUser.CausedBy(
+//			trackerr.Untracked("Row 42 missing from users").Public("User not found"),
+//		)
+//
+//		s := trackerr.RedactedStack(e)
+//
+//		// Could not load your profile
+//		// ⤷ User not found
+//
+// Use ErrorStack for logs as it still contains the full internal stack.
+func RedactedStack(e error) string {
+	sb := strings.Builder{}
+
+	WalkStack(e, func(err error, _ int) bool {
+		if msg := publicMessage(err); msg != "" {
+			sb.WriteString(DefaultFormatter(msg, err, sb.Len() == 0))
+			sb.WriteRune('\n')
+		}
+		return true
+	})
+
+	return sb.String()
+}
+
+// SquashRedacted is the same as Squash except only public messages are
+// included, see RedactedStack. The resultant error's message is also its
+// public message.
+func SquashRedacted(e error) error {
+	s := RedactedStack(e)
+	return &UntrackedError{
+		msg:    s,
+		public: s,
+		pcs:    capture(nil, 1),
+	}
+}
@@ -0,0 +1,91 @@
+package trackerr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Public_1(t *testing.T) {
+	r := IntRealm{}
+
+	a := r.New("Failed to query users table")
+	require.Equal(t, "", a.PublicMessage())
+
+	require.Same(t, a, a.Public("Could not load your profile"))
+	require.Equal(t, "Could not load your profile", a.PublicMessage())
+	require.Equal(t, "Failed to query users table", a.Error())
+
+	e := a.Because("b").(*TrackedError)
+	require.Equal(t, "Could not load your profile", e.PublicMessage())
+}
+
+func Test_Public_2(t *testing.T) {
+	a := Untracked("Row 42 missing")
+	b := a.Public("Not found")
+
+	require.Equal(t, "", a.PublicMessage())
+	require.Equal(t, "Not found", b.PublicMessage())
+	require.Equal(t, "Row 42 missing", b.Error())
+}
+
+func Test_FormatRedacted_1(t *testing.T) {
+	r := IntRealm{}
+	e := r.Coded("USR-1", "Failed to query users table").
+		Public("Could not load your profile").
+		CausedBy(
+			Untracked("Row 42 missing", KV("user", 42)).Public("User not found").CausedBy(
+				errors.New("sql: no rows in result set"),
+			),
+		)
+
+	f := FormatRedacted(FormatAttrs(FormatCode(DefaultFormatter)))
+
+	exp := "[USR-1] Could not load your profile\n" +
+		"⤷ User not found {user=42}\n" +
+		"⤷ [REDACTED]\n"
+
+	require.Equal(t, exp, ErrorStackf(e, f))
+}
+
+func Test_FormatRedacted_2(t *testing.T) {
+	r := IntRealm{}
+	nf := r.New("nf").Public("Not found")
+
+	e := nf.Because("bob@x.com not found",
+		KV("user", 42),
+		Sensitive("email", "bob@x.com"),
+	)
+
+	exp := "Not found\n" +
+		"⤷ [REDACTED] {user=42}\n"
+
+	act := ErrorStackf(e, FormatRedacted(FormatAttrs(DefaultFormatter)))
+	require.Equal(t, exp, act)
+	require.NotContains(t, ErrorStackf(e, FormatAttrs(FormatRedacted(nil))), "bob")
+}
+
+func Test_RedactedStack_1(t *testing.T) {
+	r := IntRealm{}
+	e := r.New("Failed to query users table").
+		Public("Could not load your profile").
+		CausedBy(
+			Untracked("Row 42 missing").Public("User not found").CausedBy(
+				errors.New("sql: no rows in result set"),
+			),
+		)
+
+	exp := "Could not load your profile\n" +
+		"⤷ User not found\n"
+
+	require.Equal(t, exp, RedactedStack(e))
+	require.Equal(t, "", RedactedStack(errors.New("internal")))
+
+	sq := SquashRedacted(e)
+	require.Equal(t, exp, sq.Error())
+	require.Equal(t, exp, sq.(*UntrackedError).PublicMessage())
+	require.NotContains(t, ErrorStack(sq), "users table")
+
+	require.Contains(t, ErrorStack(e), "Failed to query users table")
+}
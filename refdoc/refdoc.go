@@ -0,0 +1,354 @@
+// Package refdoc generates reference documentation for the tracked errors
+// declared within a Go module.
+//
+// Declarations are found by scanning source code for package scooped
+// variables initialised with trackerr.New, trackerr.Track, trackerr.Coded, or
+// one of the template constructors such as trackerr.NewTemplate1.
+// This means documentation is driven by the code and can't drift from it.
+//
+//		// ErrConnecting is returned when the database can't be reached.
+//		var ErrConnecting = trackerr.Coded("DB-0042", "Failed to connect")
+//
+// An error's description is taken from the argument of a chained Describe
+// call if there is one, otherwise the variable's doc comment is used.
+package refdoc
+
+import (
+	"bufio"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/PaulioRandall/go-trackerr"
+)
+
+var (
+	// ErrScanning is returned when a module's source code could not be scanned.
+	ErrScanning = trackerr.New("Failed to scan module for tracked errors")
+
+	// ErrModulePath is returned when a module's path could not be determined.
+	ErrModulePath = trackerr.New("Could not read module path")
+)
+
+// TrackerrPkg is the import path of the trackerr package.
+const TrackerrPkg = "github.com/PaulioRandall/go-trackerr"
+
+// Entry represents the declaration of a single tracked error.
+type Entry struct {
+	Code        string   `json:"code,omitempty"`
+	Message     string   `json:"message"`
+	Var         string   `json:"variable"`
+	Package     string   `json:"package"`
+	File        string   `json:"file"`
+	Line        int      `json:"line"`
+	Description string   `json:"description,omitempty"`
+	Params      []string `json:"params,omitempty"`
+}
+
+// constructors maps the trackerr functions that declare tracked errors to the
+// index of their message argument.
+var constructors = map[string]int{
+	"New":            0,
+	"Track":          0,
+	"Coded":          1,
+	"NewTemplate1":   0,
+	"NewTemplate2":   0,
+	"NewTemplate3":   0,
+	"CodedTemplate1": 1,
+	"CodedTemplate2": 1,
+	"CodedTemplate3": 1,
+}
+
+// Scan parses all Go files in the module rooted at dir and returns an Entry
+// for each tracked error declaration found.
+//
+// Test files, hidden directories, directories named testdata or vendor, and
+// nested modules, i.e. directories with their own go.mod file, are skipped.
+// Entries are ordered by file path then line number.
+func Scan(dir string) ([]Entry, error) {
+	modPath, e := readModulePath(filepath.Join(dir, "go.mod"))
+	if e != nil {
+		return nil, ErrScanning.CausedBy(e)
+	}
+
+	var entries []Entry
+	fset := token.NewFileSet()
+
+	e = filepath.WalkDir(dir, func(p string, d fs.DirEntry, e error) error {
+		if e != nil {
+			return e
+		}
+
+		if d.IsDir() {
+			if p != dir && (skipDir(d.Name()) || isModule(p)) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !isSourceFile(d.Name()) {
+			return nil
+		}
+
+		rel, e := filepath.Rel(dir, p)
+		if e != nil {
+			return e
+		}
+		rel = filepath.ToSlash(rel)
+
+		f, e := parser.ParseFile(fset, p, nil, parser.ParseComments)
+		if e != nil {
+			return e
+		}
+
+		pkg := path.Join(modPath, path.Dir(rel))
+		entries = append(entries, ScanFile(fset, f, pkg, rel)...)
+		return nil
+	})
+
+	if e != nil {
+		return nil, ErrScanning.CausedBy(e)
+	}
+	return entries, nil
+}
+
+func readModulePath(file string) (string, error) {
+	f, e := os.Open(file)
+	if e != nil {
+		return "", ErrModulePath.CausedBy(e)
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+
+		if strings.HasPrefix(line, "module ") {
+			mod := strings.TrimSpace(strings.TrimPrefix(line, "module "))
+			return strings.Trim(mod, `"`), nil
+		}
+	}
+
+	if e := sc.Err(); e != nil {
+		return "", ErrModulePath.CausedBy(e)
+	}
+	return "", ErrModulePath.Because("No module directive in '%s'", file)
+}
+
+func skipDir(name string) bool {
+	return strings.HasPrefix(name, ".") ||
+		strings.HasPrefix(name, "_") ||
+		name == "testdata" ||
+		name == "vendor"
+}
+
+// isModule returns true if the directory is the root of a nested module, i.e.
+// it contains its own go.mod file.
+func isModule(dir string) bool {
+	_, e := os.Stat(filepath.Join(dir, "go.mod"))
+	return e == nil
+}
+
+func isSourceFile(name string) bool {
+	return strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go")
+}
+
+// ScanFile returns an Entry for each tracked error declaration within the
+// parsed file. The file must have been parsed with comments for descriptions
+// to be taken from doc comments.
+//
+// This is useful for tools that load packages themselves, e.g. via
+// golang.org/x/tools/go/packages, rather than using Scan.
+func ScanFile(fset *token.FileSet, f *ast.File, pkg, file string) []Entry {
+	qualifier, ok := trackerrQualifier(f, pkg)
+	if !ok {
+		return nil
+	}
+
+	var entries []Entry
+
+	for _, d := range f.Decls {
+		gd, ok := d.(*ast.GenDecl)
+		if !ok || gd.Tok != token.VAR {
+			continue
+		}
+
+		for _, s := range gd.Specs {
+			vs := s.(*ast.ValueSpec)
+
+			for i, v := range vs.Values {
+				if i >= len(vs.Names) {
+					break
+				}
+
+				e, ok := declEntry(v, qualifier)
+				if !ok {
+					continue
+				}
+
+				e.Var = vs.Names[i].Name
+				e.Package = pkg
+				e.File = file
+				e.Line = fset.Position(vs.Names[i].Pos()).Line
+
+				if e.Description == "" {
+					e.Description = docText(gd, vs)
+				}
+
+				entries = append(entries, e)
+			}
+		}
+	}
+
+	return entries
+}
+
+// trackerrQualifier returns the name the file uses to reference the trackerr
+// package. An empty string is returned if the file is part of the trackerr
+// package itself.
+func trackerrQualifier(f *ast.File, pkg string) (string, bool) {
+	if pkg == TrackerrPkg {
+		return "", true
+	}
+
+	for _, imp := range f.Imports {
+		p, e := strconv.Unquote(imp.Path.Value)
+		if e != nil || p != TrackerrPkg {
+			continue
+		}
+
+		if imp.Name != nil {
+			return imp.Name.Name, imp.Name.Name != "_"
+		}
+		return "trackerr", true
+	}
+
+	return "", false
+}
+
+// declEntry inspects a variable's initialisation expression, unwinding any
+// chained method calls, such as Describe, until the tracked error constructor
+// is found.
+func declEntry(expr ast.Expr, qualifier string) (Entry, bool) {
+	var e Entry
+
+	for {
+		call, ok := expr.(*ast.CallExpr)
+		if !ok {
+			return Entry{}, false
+		}
+
+		if name, ok := constructorName(call.Fun, qualifier); ok {
+			return constructorEntry(e, name, call)
+		}
+
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return Entry{}, false
+		}
+
+		if sel.Sel.Name == "Describe" && len(call.Args) == 1 && e.Description == "" {
+			e.Description = stringValue(call.Args[0])
+		}
+
+		expr = sel.X
+	}
+}
+
+func constructorName(fun ast.Expr, qualifier string) (string, bool) {
+	var name string
+
+	// Strip type arguments from generic constructors.
+	switch f := fun.(type) {
+	case *ast.IndexExpr:
+		fun = f.X
+	case *ast.IndexListExpr:
+		fun = f.X
+	}
+
+	switch f := fun.(type) {
+	case *ast.Ident:
+		if qualifier != "" {
+			return "", false
+		}
+		name = f.Name
+
+	case *ast.SelectorExpr:
+		id, ok := f.X.(*ast.Ident)
+		if !ok || qualifier == "" || id.Name != qualifier {
+			return "", false
+		}
+		name = f.Sel.Name
+
+	default:
+		return "", false
+	}
+
+	_, ok := constructors[name]
+	return name, ok
+}
+
+func constructorEntry(e Entry, name string, call *ast.CallExpr) (Entry, bool) {
+	i := constructors[name]
+	if len(call.Args) <= i {
+		return Entry{}, false
+	}
+
+	if strings.HasPrefix(name, "Coded") {
+		e.Code = stringValue(call.Args[0])
+	}
+
+	e.Message = stringValue(call.Args[i])
+
+	if strings.Contains(name, "Template") {
+		for _, a := range call.Args[i+1:] {
+			e.Params = append(e.Params, stringValue(a))
+		}
+	}
+
+	return e, true
+}
+
+// stringValue returns the value of a string literal or the source form of
+// any other expression.
+func stringValue(expr ast.Expr) string {
+	switch v := expr.(type) {
+	case *ast.BasicLit:
+		if s, e := strconv.Unquote(v.Value); e == nil {
+			return s
+		}
+		return v.Value
+
+	case *ast.BinaryExpr:
+		if v.Op == token.ADD {
+			return stringValue(v.X) + stringValue(v.Y)
+		}
+
+	case *ast.Ident:
+		return v.Name
+
+	case *ast.SelectorExpr:
+		return stringValue(v.X) + "." + v.Sel.Name
+	}
+
+	return ""
+}
+
+func docText(gd *ast.GenDecl, vs *ast.ValueSpec) string {
+	doc := vs.Doc
+
+	if doc == nil && len(gd.Specs) == 1 {
+		doc = gd.Doc
+	}
+
+	if doc == nil {
+		return ""
+	}
+	return strings.TrimSpace(doc.Text())
+}
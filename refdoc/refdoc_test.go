@@ -0,0 +1,65 @@
+package refdoc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulioRandall/go-trackerr"
+)
+
+func Test_Scan_1(t *testing.T) {
+	act, e := Scan("testdata/example")
+	require.Nil(t, e)
+
+	exp := []Entry{
+		{
+			Message: "Failed to start",
+			Var:     "ErrStarting",
+			Package: "example.com/app/app",
+			File:    "app/app.go",
+			Line:    7,
+		},
+		{
+			Code:    "APP-0001",
+			Message: "User %d not found in %s",
+			Var:     "ErrUserNotFound",
+			Package: "example.com/app/app",
+			File:    "app/app.go",
+			Line:    14,
+			Params:  []string{"user", "region"},
+		},
+		{
+			Code:        "DB-0042",
+			Message:     "Failed to connect",
+			Var:         "ErrConnecting",
+			Package:     "example.com/app/db",
+			File:        "db/db.go",
+			Line:        9,
+			Description: "ErrConnecting is returned when the database can't be reached.",
+		},
+		{
+			Message:     "Query failed | rolled back",
+			Var:         "ErrQuerying",
+			Package:     "example.com/app/db",
+			File:        "db/db.go",
+			Line:        11,
+			Description: "The query could not be executed.",
+		},
+		{
+			Message:     "Connection closed",
+			Var:         "ErrClosed",
+			Package:     "example.com/app/db",
+			File:        "db/db.go",
+			Line:        18,
+			Description: "ErrClosed is returned when the connection has already been closed.",
+		},
+	}
+
+	require.Equal(t, exp, act)
+}
+
+func Test_Scan_2(t *testing.T) {
+	_, e := Scan("testdata")
+	require.True(t, trackerr.AllOrdered(e, ErrScanning, ErrModulePath))
+}
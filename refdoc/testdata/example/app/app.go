@@ -0,0 +1,14 @@
+package app
+
+import (
+	te "github.com/PaulioRandall/go-trackerr"
+)
+
+var ErrStarting = te.New("Failed to start")
+
+func run() error {
+	e := te.New("Not a package variable")
+	return e
+}
+
+var ErrUserNotFound = te.CodedTemplate2[int, string]("APP-0001", "User %d not found in %s", "user", "region")
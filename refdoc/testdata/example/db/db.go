@@ -0,0 +1,18 @@
+package db
+
+import (
+	"github.com/PaulioRandall/go-trackerr"
+)
+
+var (
+	// ErrConnecting is returned when the database can't be reached.
+	ErrConnecting = trackerr.Coded("DB-0042", "Failed to connect")
+
+	ErrQuerying = trackerr.New("Query failed | rolled back").
+			Describe("The query could not be executed.")
+
+	notAnError = "abc"
+)
+
+// ErrClosed is returned when the connection has already been closed.
+var ErrClosed = trackerr.Track("Connection closed")
@@ -0,0 +1,7 @@
+package db
+
+import (
+	"github.com/PaulioRandall/go-trackerr"
+)
+
+var errIgnored = trackerr.New("Test files are ignored")
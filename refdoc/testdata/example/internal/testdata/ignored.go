@@ -0,0 +1,7 @@
+package testdata
+
+import (
+	"github.com/PaulioRandall/go-trackerr"
+)
+
+var ErrIgnored = trackerr.New("Testdata is ignored")
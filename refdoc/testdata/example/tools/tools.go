@@ -0,0 +1,7 @@
+package tools
+
+import (
+	"github.com/PaulioRandall/go-trackerr"
+)
+
+var ErrIgnored = trackerr.New("Nested modules are ignored")
@@ -0,0 +1,103 @@
+package refdoc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Catalogue is the document written by WriteJSON.
+type Catalogue struct {
+	Errors []Entry `json:"errors"`
+}
+
+// WriteJSON writes the entries to w as an indented JSON Catalogue.
+//
+//		{
+//			"errors": [
+//				{
+//					"code": "DB-0042",
+//					"message": "Failed to connect",
+//					"variable": "ErrConnecting",
+//					"package": "example.com/app/db",
+//					"file": "db/db.go",
+//					"line": 12,
+//					"description": "The database could not be reached."
+//				}
+//			]
+//		}
+func WriteJSON(w io.Writer, entries []Entry) error {
+	if entries == nil {
+		entries = []Entry{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(Catalogue{Errors: entries})
+}
+
+// WriteMarkdown writes the entries to w as a Markdown document with a table
+// for each package. Packages appear in the order they're first found within
+// entries.
+//
+//		# Error reference
+//
+//		## example.com/app/db
+//
+//		| Code | Message | Declaration | Description |
+//		| --- | --- | --- | --- |
+//		| DB-0042 | Failed to connect | `ErrConnecting` db/db.go:12 | The database could not be reached. |
+func WriteMarkdown(w io.Writer, entries []Entry) error {
+	sb := strings.Builder{}
+	sb.WriteString("# Error reference\n")
+
+	for _, group := range groupByPackage(entries) {
+		sb.WriteString("\n## " + group[0].Package + "\n\n")
+		sb.WriteString("| Code | Message | Declaration | Description |\n")
+		sb.WriteString("| --- | --- | --- | --- |\n")
+
+		for _, e := range group {
+			writeMarkdownRow(&sb, e)
+		}
+	}
+
+	_, e := io.WriteString(w, sb.String())
+	return e
+}
+
+// groupByPackage groups the entries by package without changing their
+// relative order.
+func groupByPackage(entries []Entry) [][]Entry {
+	var groups [][]Entry
+	index := map[string]int{}
+
+	for _, e := range entries {
+		i, ok := index[e.Package]
+		if !ok {
+			i = len(groups)
+			index[e.Package] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], e)
+	}
+
+	return groups
+}
+
+func writeMarkdownRow(sb *strings.Builder, e Entry) {
+	sb.WriteString(fmt.Sprintf("| %s | %s | `%s` %s:%d | %s |\n",
+		mdCell(e.Code),
+		mdCell(e.Message),
+		e.Var,
+		e.File,
+		e.Line,
+		mdCell(e.Description),
+	))
+}
+
+func mdCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
@@ -0,0 +1,126 @@
+package refdoc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testEntries = []Entry{
+	{
+		Code:        "DB-0042",
+		Message:     "Failed to connect",
+		Var:         "ErrConnecting",
+		Package:     "example.com/app/db",
+		File:        "db/db.go",
+		Line:        9,
+		Description: "Database down.\nCheck it's running.",
+	},
+	{
+		Message: "Query failed | rolled back",
+		Var:     "ErrQuerying",
+		Package: "example.com/app/db",
+		File:    "db/db.go",
+		Line:    11,
+	},
+	{
+		Message: "Failed to start",
+		Var:     "ErrStarting",
+		Package: "example.com/app/app",
+		File:    "app/app.go",
+		Line:    7,
+	},
+}
+
+func Test_WriteMarkdown_1(t *testing.T) {
+	sb := strings.Builder{}
+
+	e := WriteMarkdown(&sb, testEntries)
+	require.Nil(t, e)
+
+	expLines := []string{
+		"# Error reference",
+		"",
+		"## example.com/app/db",
+		"",
+		"| Code | Message | Declaration | Description |",
+		"| --- | --- | --- | --- |",
+		"| DB-0042 | Failed to connect | `ErrConnecting` db/db.go:9 | Database down.<br>Check it's running. |",
+		`|  | Query failed \| rolled back | ` + "`ErrQuerying`" + ` db/db.go:11 |  |`,
+		"",
+		"## example.com/app/app",
+		"",
+		"| Code | Message | Declaration | Description |",
+		"| --- | --- | --- | --- |",
+		"|  | Failed to start | `ErrStarting` app/app.go:7 |  |",
+		"",
+	}
+	exp := strings.Join(expLines, "\n")
+
+	require.Equal(t, exp, sb.String())
+}
+
+func Test_WriteMarkdown_2(t *testing.T) {
+	entries := []Entry{
+		{Message: "a", Var: "ErrA", Package: "ex", File: "a.go", Line: 1},
+		{Message: "b", Var: "ErrB", Package: "ex/b", File: "b/b.go", Line: 1},
+		{Message: "c", Var: "ErrC", Package: "ex", File: "c.go", Line: 1},
+	}
+
+	sb := strings.Builder{}
+	require.Nil(t, WriteMarkdown(&sb, entries))
+
+	expLines := []string{
+		"# Error reference",
+		"",
+		"## ex",
+		"",
+		"| Code | Message | Declaration | Description |",
+		"| --- | --- | --- | --- |",
+		"|  | a | `ErrA` a.go:1 |  |",
+		"|  | c | `ErrC` c.go:1 |  |",
+		"",
+		"## ex/b",
+		"",
+		"| Code | Message | Declaration | Description |",
+		"| --- | --- | --- | --- |",
+		"|  | b | `ErrB` b/b.go:1 |  |",
+		"",
+	}
+
+	require.Equal(t, strings.Join(expLines, "\n"), sb.String())
+}
+
+func Test_WriteJSON_1(t *testing.T) {
+	sb := strings.Builder{}
+
+	e := WriteJSON(&sb, testEntries[2:])
+	require.Nil(t, e)
+
+	expLines := []string{
+		`{`,
+		`	"errors": [`,
+		`		{`,
+		`			"message": "Failed to start",`,
+		`			"variable": "ErrStarting",`,
+		`			"package": "example.com/app/app",`,
+		`			"file": "app/app.go",`,
+		`			"line": 7`,
+		`		}`,
+		`	]`,
+		`}`,
+		``,
+	}
+	exp := strings.Join(expLines, "\n")
+
+	require.Equal(t, exp, sb.String())
+}
+
+func Test_WriteJSON_2(t *testing.T) {
+	sb := strings.Builder{}
+
+	e := WriteJSON(&sb, nil)
+	require.Nil(t, e)
+	require.Equal(t, "{\n\t\"errors\": []\n}\n", sb.String())
+}
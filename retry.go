@@ -0,0 +1,129 @@
+package trackerr
+
+import (
+	"context"
+	"time"
+)
+
+var (
+	// ErrRetryExhausted is returned by Retry when all attempts have failed.
+	ErrRetryExhausted = New("Retry attempts exhausted")
+
+	// ErrRetryAborted is returned by Retry when the context is done, or its
+	// deadline would pass, before the next attempt.
+	ErrRetryAborted = New("Retry aborted")
+)
+
+// DefaultRetryDelay is the delay before the second attempt when a Backoff
+// doesn't specify one.
+const DefaultRetryDelay = 100 * time.Millisecond
+
+// Backoff configures the delays between Retry attempts.
+type Backoff struct {
+	// Attempts is the maximum number of attempts including the first. Zero or
+	// less means attempts continue until the context is done.
+	Attempts int
+
+	// Initial is the delay before the second attempt. Zero or less is treated
+	// as DefaultRetryDelay so retries never spin without pausing.
+	Initial time.Duration
+
+	// Max caps the delay between attempts, including the longer delays of
+	// Throttled errors. Zero or less means no cap.
+	Max time.Duration
+
+	// Multiplier scales the delay after each attempt. Values less than one
+	// are treated as two.
+	Multiplier float64
+}
+
+// Retry calls f until it succeeds, returns an error that is not retryable,
+// the attempts run out, or the context is done.
+//
+// Errors are classified using Classify. Only Transient and Throttled errors
+// are retried. Throttled errors wait twice as long as the current delay.
+//
+//		b := trackerr.Backoff{
+//			Attempts: 5,
+//			Initial:  100 * time.Millisecond,
+//			Max:      2 * time.Second,
+//		}
+//
+//		e := trackerr.Retry(ctx, b, func(ctx context.Context) error {
+//			return client.Send(ctx, msg)
+//		})
+//
+// Non-retryable errors are returned as is. When attempts run out the last
+// error is returned wrapped by ErrRetryExhausted. If the context is done, or
+// its deadline would pass before the next attempt, the context's error and
+// the last error are returned aggregated beneath ErrRetryAborted.
+func Retry(ctx context.Context, b Backoff, f func(ctx context.Context) error) error {
+	delay := b.Initial
+	if delay <= 0 {
+		delay = DefaultRetryDelay
+	}
+
+	multiplier := b.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	var last error
+
+	for attempt := 1; ; attempt++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ErrRetryAborted.CausedByAll(ctxErr, last)
+		}
+
+		e := f(ctx)
+		if e == nil {
+			return nil
+		}
+		last = e
+
+		c := Classify(e)
+		if !c.Retryable() {
+			return e
+		}
+
+		if b.Attempts > 0 && attempt >= b.Attempts {
+			return ErrRetryExhausted.CausedBy(e)
+		}
+
+		wait := delay
+		if c == Throttled {
+			wait *= 2
+		}
+
+		if b.Max > 0 && wait > b.Max {
+			wait = b.Max
+		}
+
+		if ctxErr := sleep(ctx, wait); ctxErr != nil {
+			return ErrRetryAborted.CausedByAll(ctxErr, e)
+		}
+
+		delay = time.Duration(float64(delay) * multiplier)
+		if b.Max > 0 && delay > b.Max {
+			delay = b.Max
+		}
+	}
+}
+
+// sleep waits for the duration d unless the context is done first or its
+// deadline would pass before d elapses.
+func sleep(ctx context.Context, d time.Duration) error {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < d {
+		return context.DeadlineExceeded
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,157 @@
+package trackerr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testBackoff = Backoff{
+	Attempts: 3,
+	Initial:  time.Millisecond,
+	Max:      2 * time.Millisecond,
+}
+
+func Test_Retry_1(t *testing.T) {
+	r := IntRealm{}
+	transient := r.New("transient").Transient()
+
+	calls := 0
+	e := Retry(context.Background(), testBackoff, func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return transient.Because("attempt %d", calls)
+		}
+		return nil
+	})
+
+	require.Nil(t, e)
+	require.Equal(t, 3, calls)
+}
+
+func Test_Retry_2(t *testing.T) {
+	r := IntRealm{}
+	permanent := r.New("permanent").Permanent()
+	none := r.New("none")
+
+	for _, given := range []*TrackedError{permanent, none} {
+		calls := 0
+		e := Retry(context.Background(), testBackoff, func(ctx context.Context) error {
+			calls++
+			return given
+		})
+
+		require.Same(t, given, e)
+		require.Equal(t, 1, calls)
+	}
+}
+
+func Test_Retry_3(t *testing.T) {
+	r := IntRealm{}
+	throttled := r.New("throttled").Throttled()
+
+	calls := 0
+	e := Retry(context.Background(), testBackoff, func(ctx context.Context) error {
+		calls++
+		return throttled
+	})
+
+	require.True(t, AllOrdered(e, ErrRetryExhausted, throttled))
+	require.Equal(t, 3, calls)
+}
+
+func Test_Retry_4(t *testing.T) {
+	r := IntRealm{}
+	transient := r.New("transient").Transient()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	calls := 0
+	b := Backoff{Initial: time.Hour}
+
+	e := Retry(ctx, b, func(ctx context.Context) error {
+		calls++
+		return transient
+	})
+
+	require.True(t, All(e, ErrRetryAborted, transient, context.DeadlineExceeded))
+	require.Equal(t, 1, calls)
+}
+
+func Test_Retry_5(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	e := Retry(ctx, testBackoff, func(ctx context.Context) error {
+		require.Fail(t, "Should not be called")
+		return nil
+	})
+
+	require.True(t, All(e, ErrRetryAborted, context.Canceled))
+}
+
+func Test_Retry_6(t *testing.T) {
+	r := IntRealm{}
+	transient := r.New("transient").Transient()
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultRetryDelay+50*time.Millisecond)
+	defer cancel()
+
+	calls := 0
+	e := Retry(ctx, Backoff{}, func(ctx context.Context) error {
+		calls++
+		return transient
+	})
+
+	require.True(t, All(e, ErrRetryAborted, transient))
+	require.LessOrEqual(t, calls, 2)
+}
+
+func Test_Retry_7(t *testing.T) {
+	r := IntRealm{}
+	throttled := r.New("throttled").Throttled()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Millisecond)
+	defer cancel()
+
+	calls := 0
+	b := Backoff{Attempts: 2, Initial: 40 * time.Millisecond, Max: 40 * time.Millisecond}
+
+	e := Retry(ctx, b, func(ctx context.Context) error {
+		calls++
+		return throttled
+	})
+
+	require.True(t, AllOrdered(e, ErrRetryExhausted, throttled))
+	require.Equal(t, 2, calls)
+}
+
+// doneAfterCtx reports its error after Err has been called n times without
+// ever closing its Done channel.
+type doneAfterCtx struct {
+	context.Context
+	n int
+}
+
+func (c *doneAfterCtx) Err() error {
+	if c.n--; c.n < 0 {
+		return context.Canceled
+	}
+	return nil
+}
+
+func Test_Retry_8(t *testing.T) {
+	r := IntRealm{}
+	transient := r.New("transient").Transient()
+
+	ctx := &doneAfterCtx{Context: context.Background(), n: 1}
+
+	e := Retry(ctx, testBackoff, func(ctx context.Context) error {
+		return transient
+	})
+
+	require.True(t, All(e, ErrRetryAborted, context.Canceled, transient))
+}
@@ -0,0 +1,173 @@
+package trackerr
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+)
+
+// LogValue satisfies slog.LogValuer so the whole error stack is logged as a
+// structured group, see StackValue.
+func (e TrackedError) LogValue() slog.Value {
+	return StackValue(&e)
+}
+
+// LogValue satisfies slog.LogValuer so the whole error stack is logged as a
+// structured group, see StackValue.
+func (e UntrackedError) LogValue() slog.Value {
+	return StackValue(&e)
+}
+
+// StackValue returns a slog group Value describing the error and every error
+// in its stack. Any error may be passed, not just those from this package.
+//
+// The group contains the head's message and a 'stack' group with one group
+// per error, keyed by position in the order they're visited by WalkStack.
+// Each contains the error's own message plus, if it has them, its ID, code,
+// attributes, and call site. Errors other than the head also contain their
+// depth so trees, such as those created by CausedByAll, can be reassembled.
+//
+//		slog.Error("Workflow failed", slog.Any("err", trackerr.StackValue(e)))
+//
+//		// {
+//		// 	"msg": "Workflow failed",
+//		// 	"err": {
+//		// 		"msg": "Failed to load data",
+//		// 		"stack": {
+//		// 			"0": {"msg": "Failed to load data", "id": 4, "code": "DB-0001"},
+//		// 			"1": {"msg": "File not found", "attrs": {"path": "data.csv"}, "depth": 1}
+//		// 		}
+//		// 	}
+//		// }
+func StackValue(e error) slog.Value {
+	if e == nil {
+		return slog.Value{}
+	}
+
+	var frames []slog.Attr
+
+	WalkStack(e, func(err error, depth int) bool {
+		frames = append(frames, slog.Attr{
+			Key:   strconv.Itoa(len(frames)),
+			Value: frameValue(err, depth),
+		})
+		return true
+	})
+
+	return slog.GroupValue(
+		slog.String("msg", e.Error()),
+		slog.Attr{Key: "stack", Value: slog.GroupValue(frames...)},
+	)
+}
+
+func frameValue(e error, depth int) slog.Value {
+	var attrs []slog.Attr
+
+	if te, ok := asTracked(e); ok {
+		e = te
+	}
+
+	switch v := e.(type) {
+	case *TrackedError:
+		attrs = append(attrs, slog.String("msg", v.msg), slog.Int("id", v.id))
+		if v.code != "" {
+			attrs = append(attrs, slog.String("code", v.code))
+		}
+
+	case *UntrackedError:
+		attrs = append(attrs, slog.String("msg", v.msg))
+
+	default:
+		attrs = append(attrs, slog.String("msg", ErrorWithoutCause(e)))
+	}
+
+	if own := ownAttrs(e); len(own) > 0 {
+		group := make([]slog.Attr, len(own))
+		for i, a := range own {
+			group[i] = slog.Any(a.Key, a.Value)
+		}
+		attrs = append(attrs, slog.Attr{Key: "attrs", Value: slog.GroupValue(group...)})
+	}
+
+	if cs, ok := e.(interface{ CallSite() (Frame, bool) }); ok {
+		if f, ok := cs.CallSite(); ok {
+			attrs = append(attrs, slog.String("site", f.String()))
+		}
+	}
+
+	if depth > 0 {
+		attrs = append(attrs, slog.Int("depth", depth))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// SlogHandler is a slog.Handler that wraps another so error attributes of any
+// type are logged as structured groups via StackValue.
+//
+//		logger := slog.New(trackerr.NewSlogHandler(slog.NewJSONHandler(os.Stdout, nil)))
+//		logger.Error("Workflow failed", "err", e)
+//
+// Errors from this package are logged this way without the SlogHandler, it's
+// only needed for errors from other packages such as those created via
+// fmt.Errorf that wrap errors from this package.
+type SlogHandler struct {
+	h slog.Handler
+}
+
+// NewSlogHandler returns a new SlogHandler wrapping h.
+func NewSlogHandler(h slog.Handler) *SlogHandler {
+	return &SlogHandler{h: h}
+}
+
+// Enabled satisfies slog.Handler.
+func (h *SlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.h.Enabled(ctx, level)
+}
+
+// Handle satisfies slog.Handler.
+func (h *SlogHandler) Handle(ctx context.Context, r slog.Record) error {
+	nr := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+
+	r.Attrs(func(a slog.Attr) bool {
+		nr.AddAttrs(expandErrAttr(a))
+		return true
+	})
+
+	return h.h.Handle(ctx, nr)
+}
+
+// WithAttrs satisfies slog.Handler.
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	expanded := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		expanded[i] = expandErrAttr(a)
+	}
+
+	return NewSlogHandler(h.h.WithAttrs(expanded))
+}
+
+// WithGroup satisfies slog.Handler.
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	return NewSlogHandler(h.h.WithGroup(name))
+}
+
+func expandErrAttr(a slog.Attr) slog.Attr {
+	switch a.Value.Kind() {
+	case slog.KindAny:
+		if e, ok := a.Value.Any().(error); ok {
+			a.Value = StackValue(e)
+		}
+
+	case slog.KindGroup:
+		group := a.Value.Group()
+		expanded := make([]slog.Attr, len(group))
+
+		for i, ga := range group {
+			expanded[i] = expandErrAttr(ga)
+		}
+		a.Value = slog.GroupValue(expanded...)
+	}
+
+	return a
+}
@@ -0,0 +1,135 @@
+package trackerr
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func logJSON(t *testing.T, h func(slog.Handler) slog.Handler, args ...any) map[string]any {
+	buf := bytes.Buffer{}
+	var handler slog.Handler = slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+
+	if h != nil {
+		handler = h(handler)
+	}
+
+	slog.New(handler).Error("failed", args...)
+
+	m := map[string]any{}
+	require.Nil(t, json.Unmarshal(buf.Bytes(), &m))
+	return m
+}
+
+func Test_StackValue_1(t *testing.T) {
+	r := IntRealm{}
+	abc := r.Coded("ABC-1", "abc")
+	efg := r.New("efg")
+
+	e := abc.CausedBy(efg.Because("hij", KV("x", 1)))
+	act := logJSON(t, nil, "err", e)
+
+	exp := map[string]any{
+		"level": "ERROR",
+		"msg":   "failed",
+		"err": map[string]any{
+			"msg": "abc",
+			"stack": map[string]any{
+				"0": map[string]any{"msg": "abc", "id": 1.0, "code": "ABC-1"},
+				"1": map[string]any{"msg": "efg", "id": 2.0, "depth": 1.0},
+				"2": map[string]any{"msg": "hij", "attrs": map[string]any{"x": 1.0}, "depth": 2.0},
+			},
+		},
+	}
+
+	require.Equal(t, exp, act)
+}
+
+func Test_StackValue_2(t *testing.T) {
+	r := IntRealm{}
+	r.SetCapture(CaptureCaller)
+
+	e := r.New("abc").Because("efg")
+	v := StackValue(e).Group()[1].Value.Group()[0].Value
+
+	site := v.Group()[2]
+	require.Equal(t, "site", site.Key)
+	require.Regexp(t, `^go-trackerr\.Test_StackValue_2 slog_test\.go:\d+$`, site.Value.String())
+}
+
+func Test_StackValue_3(t *testing.T) {
+	a, b := Untracked("a"), Untracked("b")
+	e := a.CausedByAll(b.Because("b1"), Untracked("c"))
+
+	act := logJSON(t, nil, "err", e)
+
+	exp := map[string]any{
+		"msg": "a",
+		"stack": map[string]any{
+			"0": map[string]any{"msg": "a"},
+			"1": map[string]any{"msg": "b\nc", "depth": 1.0},
+			"2": map[string]any{"msg": "b", "depth": 2.0},
+			"3": map[string]any{"msg": "b1", "depth": 3.0},
+			"4": map[string]any{"msg": "c", "depth": 2.0},
+		},
+	}
+	require.Equal(t, exp, act["err"])
+}
+
+func Test_SlogHandler_1(t *testing.T) {
+	e := fmt.Errorf("abc: %w", Untracked("efg"))
+
+	act := logJSON(t, nil, "err", e)
+	require.Equal(t, "abc: efg", act["err"])
+
+	act = logJSON(t, func(h slog.Handler) slog.Handler {
+		return NewSlogHandler(h)
+	}, "err", e, slog.Group("g", "err", errors.New("hij")))
+
+	exp := map[string]any{
+		"msg": "abc: efg",
+		"stack": map[string]any{
+			"0": map[string]any{"msg": "abc"},
+			"1": map[string]any{"msg": "efg", "depth": 1.0},
+		},
+	}
+	require.Equal(t, exp, act["err"])
+
+	exp = map[string]any{
+		"err": map[string]any{
+			"msg": "hij",
+			"stack": map[string]any{
+				"0": map[string]any{"msg": "hij"},
+			},
+		},
+	}
+	require.Equal(t, exp, act["g"])
+}
+
+func Test_SlogHandler_2(t *testing.T) {
+	act := logJSON(t, func(h slog.Handler) slog.Handler {
+		return NewSlogHandler(h).WithAttrs([]slog.Attr{
+			slog.Any("err", errors.New("abc")),
+		})
+	})
+
+	exp := map[string]any{
+		"msg": "abc",
+		"stack": map[string]any{
+			"0": map[string]any{"msg": "abc"},
+		},
+	}
+	require.Equal(t, exp, act["err"])
+}
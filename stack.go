@@ -19,27 +19,27 @@ type ErrorFormatter func(errMsg string, e error, isFirst bool) string
 //		⤷ open splay/example/data/acid-rain.csv
 //		⤷ no such file or directory
 func ErrorStack(e error) string {
-	return ErrorStackf(e, func(errMsg string, e error, isFirst bool) string {
-		sb := strings.Builder{}
-
-		if !isFirst {
-			sb.WriteString("⤷ ")
-		}
-
-		sb.WriteString(errMsg)
-		return sb.String()
-	})
+	return ErrorStackf(e, DefaultFormatter)
 }
 
 // ErrorStackf returns a human readable stack trace for the error. The format
 // function f may be nil for no formatting.
 //
+// Errors that wrap multiple causes, such as those created via errors.Join,
+// have each cause drawn as an indented branch. Errors that only join their
+// causes, without a message of their own, are omitted.
+//
+//		Validation failed
+//		├ ⤷ Name is required
+//		└ ⤷ Age is invalid
+//		  ⤷ Must be positive
+//
 //		alice := trackerr.Untracked("Alice's message")
 //		bob := trackerr.Checkpoint(alice, "Bob's message")
 //		charlie := trackerr.Wrap(bob, "Charlie's message")
 //		dan := trackerr.Wrap(charlie, "Dan's message")
 //
-//		s := trackerr.ErrorStackf(e, func(errMsg string, err error, isFirst bool) string {
+//		s := trackerr.ErrorStackf(dan, func(errMsg string, err error, isFirst bool) string {
 //			if isFirst {
 //				return "ERROR: " + errMsg
 //			}
@@ -54,20 +54,9 @@ func ErrorStack(e error) string {
 //		// *** Bob's message ***
 //		// Caused by: Alice's message
 func ErrorStackf(e error, f ErrorFormatter) string {
-	sb := strings.Builder{}
-
-	for i, cause := range SliceStack(e) {
-		errMsg := cause.Error()
-
-		if f != nil {
-			errMsg = f(errMsg, cause, i == 0)
-		}
-
-		sb.WriteString(errMsg)
-		sb.WriteRune('\n')
-	}
-
-	return sb.String()
+	w := stackWriter{f: f}
+	w.write(e, "", "", true)
+	return w.sb.String()
 }
 
 // SliceStack recursively unwraps the error returning a slice of errors. The
@@ -84,13 +73,17 @@ func ErrorStackf(e error, f ErrorFormatter) string {
 //		// 	bob,
 //		// 	charlie,
 //		// ]
+//
+// Errors that wrap multiple causes, such as those created via errors.Join,
+// are flattened in the order WalkStack visits them. Use TreeStack to keep
+// their structure.
 func SliceStack(e error) []error {
 	var stack []error
 
-	for e != nil {
-		stack = append(stack, e)
-		e = errors.Unwrap(e)
-	}
+	WalkStack(e, func(err error, _ int) bool {
+		stack = append(stack, err)
+		return true
+	})
 
 	return stack
 }
@@ -104,7 +97,7 @@ func ErrorWithoutCause(e error) string {
 		return s
 	}
 
-	if _, ok := e.(*TrackedError); ok {
+	if _, ok := asTracked(e); ok {
 		return s
 	}
 
@@ -134,12 +127,19 @@ func ErrorWithoutCause(e error) string {
 //		// ⤷ mid level message
 //		// ⤷ root cause message
 func Stack(e error, errs ...ErrorThatWraps) error {
+	return stack(1, e, errs...)
+}
+
+// stack is Stack but with the number of frames to skip to reach the caller
+// whose call site should be recorded.
+func stack(skip int, e error, errs ...ErrorThatWraps) error {
 	if e == nil {
 		return nil
 	}
 
 	for _, err := range errs {
 		e = err.CausedBy(e).(ErrorThatWraps)
+		recapture(e, skip+1)
 	}
 
 	return e
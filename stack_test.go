@@ -46,6 +46,9 @@ func Test_SliceStack_1(t *testing.T) {
 }
 
 func Test_DebugPanic_1(t *testing.T) {
+	sb, reset := withDebugSink()
+	defer reset()
+
 	a := New("a")
 
 	given := func() (e error) {
@@ -61,6 +64,7 @@ func Test_DebugPanic_1(t *testing.T) {
 	e := given()
 
 	require.Equal(t, e, a)
+	require.Equal(t, "[DEBUG ERROR]\n  a\n", sb.String())
 }
 
 func Test_Stack_1(t *testing.T) {
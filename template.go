@@ -0,0 +1,144 @@
+package trackerr
+
+// Template1 is a tracked error whose message is a template with one typed
+// parameter. Use With to create errors from it.
+type Template1[A any] struct {
+	*TrackedError
+	names [1]string
+}
+
+// Template2 is a tracked error whose message is a template with two typed
+// parameters. Use With to create errors from it.
+type Template2[A, B any] struct {
+	*TrackedError
+	names [2]string
+}
+
+// Template3 is a tracked error whose message is a template with three typed
+// parameters. Use With to create errors from it.
+type Template3[A, B, C any] struct {
+	*TrackedError
+	names [3]string
+}
+
+// NewTemplate1 returns a new tracked error, from this package's global Realm,
+// whose message is a template with one parameter. The name is used as the
+// parameter's attribute key.
+//
+//		var ErrUserNotFound = trackerr.NewTemplate1[int]("User %d not found", "user")
+//
+//		e := ErrUserNotFound.With(42)
+//
+//		// e.Error(): "User 42 not found"
+//		// e.Attrs(): [user=42]
+//		// errors.Is(e, ErrUserNotFound): true
+//
+// The template is not formatted until With is called so the declared error's
+// message is the raw template.
+//
+// Templates can only be declared within the global Realm because Go doesn't
+// allow methods with type parameters. Errors in other Realms can use Because
+// with attributes instead.
+func NewTemplate1[A any](msg, name string) *Template1[A] {
+	checkInitState()
+	return template1[A](globalRealm.template("", msg), name)
+}
+
+// NewTemplate2 is the same as NewTemplate1 but for templates with two
+// parameters.
+func NewTemplate2[A, B any](msg, nameA, nameB string) *Template2[A, B] {
+	checkInitState()
+	return template2[A, B](globalRealm.template("", msg), nameA, nameB)
+}
+
+// NewTemplate3 is the same as NewTemplate1 but for templates with three
+// parameters.
+func NewTemplate3[A, B, C any](msg, nameA, nameB, nameC string) *Template3[A, B, C] {
+	checkInitState()
+	return template3[A, B, C](globalRealm.template("", msg), nameA, nameB, nameC)
+}
+
+// CodedTemplate1 is the same as NewTemplate1 but the error has a stable code,
+// see Coded.
+//
+//		var ErrUserNotFound = trackerr.CodedTemplate1[int]("USR-0001", "User %d not found", "user")
+func CodedTemplate1[A any](code, msg, name string) *Template1[A] {
+	checkInitState()
+	checkCode(code)
+	return template1[A](globalRealm.template(code, msg), name)
+}
+
+// CodedTemplate2 is the same as NewTemplate2 but the error has a stable code,
+// see Coded.
+func CodedTemplate2[A, B any](code, msg, nameA, nameB string) *Template2[A, B] {
+	checkInitState()
+	checkCode(code)
+	return template2[A, B](globalRealm.template(code, msg), nameA, nameB)
+}
+
+// CodedTemplate3 is the same as NewTemplate3 but the error has a stable code,
+// see Coded.
+func CodedTemplate3[A, B, C any](code, msg, nameA, nameB, nameC string) *Template3[A, B, C] {
+	checkInitState()
+	checkCode(code)
+	return template3[A, B, C](globalRealm.template(code, msg), nameA, nameB, nameC)
+}
+
+func template1[A any](e *TrackedError, name string) *Template1[A] {
+	return &Template1[A]{
+		TrackedError: e,
+		names:        [1]string{name},
+	}
+}
+
+func template2[A, B any](e *TrackedError, nameA, nameB string) *Template2[A, B] {
+	return &Template2[A, B]{
+		TrackedError: e,
+		names:        [2]string{nameA, nameB},
+	}
+}
+
+func template3[A, B, C any](e *TrackedError, nameA, nameB, nameC string) *Template3[A, B, C] {
+	return &Template3[A, B, C]{
+		TrackedError: e,
+		names:        [3]string{nameA, nameB, nameC},
+	}
+}
+
+// With returns a copy of the tracked error with its message formatted from
+// the template and parameter. The copy has the same tracking ID and the raw
+// parameter is attached as an attribute.
+func (t Template1[A]) With(a A) *TrackedError {
+	e := t.with(t.names[:], a)
+	e.pcs = capture(e.realm, 1)
+	return e
+}
+
+// With returns a copy of the tracked error with its message formatted from
+// the template and parameters. The copy has the same tracking ID and the raw
+// parameters are attached as attributes.
+func (t Template2[A, B]) With(a A, b B) *TrackedError {
+	e := t.with(t.names[:], a, b)
+	e.pcs = capture(e.realm, 1)
+	return e
+}
+
+// With returns a copy of the tracked error with its message formatted from
+// the template and parameters. The copy has the same tracking ID and the raw
+// parameters are attached as attributes.
+func (t Template3[A, B, C]) With(a A, b B, c C) *TrackedError {
+	e := t.with(t.names[:], a, b, c)
+	e.pcs = capture(e.realm, 1)
+	return e
+}
+
+func (e TrackedError) with(names []string, params ...any) *TrackedError {
+	attrs := make([]Attr, len(params))
+	for i, p := range params {
+		attrs[i] = KV(names[i], p)
+	}
+
+	e.msg = fmtMsg(e.Template(), params...)
+	e.attrs = appendAttrs(e.attrs, attrs)
+	return &e
+}
@@ -0,0 +1,85 @@
+package trackerr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errTemplate = CodedTemplate2[string, int]("TEST-TMPL", "%s %d", "a", "b")
+
+func Test_Template1_1(t *testing.T) {
+	r := IntRealm{}
+	r.SetCapture(CaptureCaller)
+	tmpl := template1[int](r.template("USR-1", "User %d not found"), "user")
+
+	require.Equal(t, "User %d not found", tmpl.Error())
+
+	e := tmpl.With(42)
+	require.Equal(t, "User 42 not found", e.Error())
+	require.Equal(t, []Attr{KV("user", 42)}, e.Attrs())
+	require.Equal(t, tmpl.ID(), e.ID())
+	require.Equal(t, "USR-1", e.Code())
+	require.Equal(t, "User %d not found", e.Template())
+
+	require.True(t, errors.Is(e, tmpl))
+	require.True(t, errors.Is(r.New("a").CausedBy(e), tmpl))
+	require.True(t, errors.Is(tmpl, e))
+	require.False(t, errors.Is(r.New("b"), tmpl))
+
+	requireCallSite(t, e, "Test_Template1_1")
+}
+
+func Test_Template2_1(t *testing.T) {
+	r := IntRealm{}
+	te := r.template("", "Failed to read %s at line %d").WithAttrs(KV("op", "read"))
+	tmpl := template2[string, int](te, "file", "line")
+
+	e := tmpl.With("data.csv", 7)
+	require.Equal(t, "Failed to read data.csv at line 7", e.Error())
+	require.Equal(t, []Attr{KV("op", "read"), KV("file", "data.csv"), KV("line", 7)}, e.Attrs())
+	require.True(t, errors.Is(e, tmpl))
+
+	require.Equal(t, []Attr{KV("op", "read")}, tmpl.Attrs())
+}
+
+func Test_Template3_1(t *testing.T) {
+	r := IntRealm{}
+	tmpl := template3[string, int, bool](r.template("", "%s %d %t"), "a", "b", "c")
+
+	e := tmpl.With("x", 1, true)
+	require.Equal(t, "x 1 true", e.Error())
+	require.Equal(t, []Attr{KV("a", "x"), KV("b", 1), KV("c", true)}, e.Attrs())
+	require.True(t, errors.Is(e, tmpl))
+}
+
+func Test_CodedTemplate2_1(t *testing.T) {
+	e := errTemplate.With("a", 1)
+
+	require.Equal(t, "a 1", e.Error())
+	require.Equal(t, "github.com/PaulioRandall/go-trackerr", e.Package())
+
+	act, ok := LookupCode("TEST-TMPL")
+	require.True(t, ok)
+	require.Same(t, errTemplate.TrackedError, act)
+	require.Equal(t, "%s %d", act.Error())
+}
+
+func Test_Template1_2(t *testing.T) {
+	r := IntRealm{}
+	tmpl := template1[int](r.template("USR-2", "User %d not found"), "user")
+	tmpl.HTTP(404, "").Public("User not found").Permanent()
+
+	require.True(t, IsTracked(tmpl))
+	require.True(t, HasTracked(Untracked("a").CausedBy(tmpl)))
+	require.Equal(t, Permanent, Classify(tmpl))
+
+	status, _ := ResolveStatus(tmpl)
+	require.Equal(t, 404, status)
+	require.Equal(t, "USR-2", NewProblem(tmpl).Code)
+
+	require.Equal(t, "[USR-2] User %d not found\n", ErrorStackf(tmpl, FormatCode(nil)))
+	require.Equal(t, "USR-2", NewJSONStack(tmpl).Stack[0].Code)
+	require.Equal(t, "User %d not found", ErrorWithoutCause(tmpl))
+}
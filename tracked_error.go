@@ -2,14 +2,34 @@ package trackerr
 
 // TrackedError represents a trackable node in an error stack.
 type TrackedError struct {
+	realm Realm
 	id    int
+	code  string
 	msg   string
 	cause error
+	attrs []Attr
+	decl  *declaration
+	pcs   []uintptr
+}
+
+// declaration holds information about where and how a tracked error was
+// declared. It is shared between a tracked error and all of its copies.
+type declaration struct {
+	pkg   string
+	tmpl  string
+	desc  string
+	class Class
+
+	status int
+	title  string
+
+	public string
 }
 
 // New is an alias for Track.
 func New(msg string, args ...any) *TrackedError {
-	return Track(msg, args...)
+	checkInitState()
+	return globalRealm.track("", msg, args...)
 }
 
 // Track returns a new tracked error from this package's global Realm.
@@ -17,7 +37,41 @@ func New(msg string, args ...any) *TrackedError {
 // This is the recommended way to use to create all trackable errors.
 func Track(msg string, args ...any) *TrackedError {
 	checkInitState()
-	return globalRealm.Track(msg, args...)
+	return globalRealm.track("", msg, args...)
+}
+
+// Coded returns a new tracked error, with a stable code, from this package's
+// global Realm.
+//
+//		var ErrConnecting = trackerr.Coded("DB-0042", "Failed to connect")
+//
+// Coded panics if the code is empty or has already been used.
+func Coded(code, msg string, args ...any) *TrackedError {
+	checkInitState()
+	checkCode(code)
+	return globalRealm.track(code, msg, args...)
+}
+
+// Lookup returns the tracked error, from this package's global Realm, with the
+// ID id.
+//
+// This can be useful for decoding IDs found in logs.
+func Lookup(id int) (*TrackedError, bool) {
+	return globalRealm.Lookup(id)
+}
+
+// LookupCode returns the tracked error, from this package's global Realm,
+// with the code.
+func LookupCode(code string) (*TrackedError, bool) {
+	return globalRealm.LookupCode(code)
+}
+
+// Errors returns all tracked errors in this package's global Realm in
+// declaration order.
+//
+// This is useful for building catalogues of all errors a program may return.
+func Errors() []*TrackedError {
+	return globalRealm.Errors()
 }
 
 // Because constructs a cause from msg and args.
@@ -30,8 +84,13 @@ func Track(msg string, args ...any) *TrackedError {
 //		wrapper message
 //		⤷ cause message
 //		```
+//
+// Any Attr values within args are removed before formatting and attached to
+// the new cause instead.
 func (e TrackedError) Because(msg string, args ...any) error {
-	return e.BecauseOf(nil, msg, args...)
+	e.pcs = capture(e.realm, 1)
+	e.cause = because(msg, args...)
+	return &e
 }
 
 // BecauseOf creates a new error using the msg, args, and cause as arguments
@@ -51,7 +110,8 @@ func (e TrackedError) Because(msg string, args ...any) error {
 //		⤷ root cause message
 //		```
 func (e TrackedError) BecauseOf(rootCause error, msg string, args ...any) error {
-	e.cause = Untracked(msg, args...).CausedBy(rootCause)
+	e.pcs = capture(e.realm, 1)
+	e.cause = causedBy(rootCause, msg, args...)
 	return &e
 }
 
@@ -85,11 +145,41 @@ func (e TrackedError) BecauseOf(rootCause error, msg string, args ...any) error
 //		⤷ cause message
 //		```
 func (e TrackedError) CausedBy(rootCause error, causes ...ErrorThatWraps) error {
-	c := Stack(rootCause, causes...)
-	e.cause = c
+	e.pcs = capture(e.realm, 1)
+	e.cause = stack(1, rootCause, causes...)
 	return &e
 }
 
+// CallSite returns the location where the error was created or wrapped.
+//
+// Call sites are only recorded when capturing is enabled, see SetCapture.
+func (e TrackedError) CallSite() (Frame, bool) {
+	return callSite(e.pcs)
+}
+
+// WithAttrs returns a copy of the error with the attributes appended to its
+// own. This is useful for attaching attributes before calling CausedBy.
+//
+//		e := ErrLoadingData.WithAttrs(trackerr.KV("retries", 3)).CausedBy(cause)
+func (e TrackedError) WithAttrs(attrs ...Attr) *TrackedError {
+	e.attrs = appendAttrs(e.attrs, attrs)
+	return &e
+}
+
+// Attrs returns the error's own attributes, not those of its causes. See
+// CollectAttrs for gathering attributes from the whole stack.
+func (e TrackedError) Attrs() []Attr {
+	return e.attrs
+}
+
+// StackTrace returns the call stack at the point the error was created or
+// wrapped. If only the call site was recorded then it will be the only Frame.
+//
+// Stacks are only recorded when capturing is enabled, see SetCapture.
+func (e TrackedError) StackTrace() []Frame {
+	return stackTrace(e.pcs)
+}
+
 // Error satisfies the error interface.
 func (e TrackedError) Error() string {
 	return e.msg
@@ -98,13 +188,100 @@ func (e TrackedError) Error() string {
 // Is returns true if the passed error is equivalent to the receiving
 // error. This is a shallow comparison so causes are not checked.
 //
+// Errors are only equivalent if they were created by the same Realm and have
+// the same ID within it. Errors from different Realms never match, even if
+// they share a code.
+//
 // It satisfies the Is function referenced by errors.Is in the standard errors
 // package.
 func (e TrackedError) Is(other error) bool {
-	if e2, ok := other.(*TrackedError); ok {
-		return e.id == e2.id
+	t, ok := other.(tracker)
+	if !ok {
+		return false
+	}
+
+	e2 := t.tracked()
+	return e.realm == e2.realm && e.id == e2.id
+}
+
+// tracker is implemented by TrackedError and anything embedding it, such as
+// templates, so they can be compared via Is.
+type tracker interface {
+	tracked() *TrackedError
+}
+
+func (e *TrackedError) tracked() *TrackedError {
+	return e
+}
+
+// asTracked returns the tracked error e is, or embeds in the case of
+// templates.
+func asTracked(e error) (*TrackedError, bool) {
+	if t, ok := e.(tracker); ok {
+		return t.tracked(), true
+	}
+	return nil, false
+}
+
+// ID returns the error's tracking ID which is unique within its Realm.
+func (e TrackedError) ID() int {
+	return e.id
+}
+
+// Package returns the import path of the package that declared the error.
+func (e TrackedError) Package() string {
+	if e.decl == nil {
+		return ""
 	}
-	return false
+	return e.decl.pkg
+}
+
+// Template returns the unformatted message used to declare the error.
+func (e TrackedError) Template() string {
+	if e.decl == nil {
+		return ""
+	}
+	return e.decl.tmpl
+}
+
+// Describe attaches a longer, human readable, description to the tracked
+// error then returns it. Descriptions are included in generated error
+// reference documentation.
+//
+//		var ErrConnecting = trackerr.Coded("DB-0042", "Failed to connect").
+//			Describe("The database could not be reached, check it's running.")
+//
+// Like New and Track, it should only be called during package initialisation
+// because the description is shared with all copies of the error.
+func (e *TrackedError) Describe(desc string) *TrackedError {
+	if e.decl == nil {
+		e.decl = &declaration{}
+	}
+	e.decl.desc = desc
+	return e
+}
+
+// Description returns the error's description or an empty string if it
+// doesn't have one.
+func (e TrackedError) Description() string {
+	if e.decl == nil {
+		return ""
+	}
+	return e.decl.desc
+}
+
+// Code returns the error's stable code or an empty string if it doesn't
+// have one.
+func (e TrackedError) Code() string {
+	return e.code
+}
+
+// Realm returns the Realm that created the error.
+//
+// Errors created via the package scooped New and Track functions belong to
+// the package's private global Realm.
+func (e TrackedError) Realm() Realm {
+	return e.realm
 }
 
 // Unwrap returns the error's underlying cause or nil if none exists.
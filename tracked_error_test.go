@@ -38,3 +38,74 @@ func Test_TrackedError_2(t *testing.T) {
 
 	require.False(t, a.Is(b))
 }
+
+func Test_TrackedError_3(t *testing.T) {
+	r := IntRealm{}
+
+	a := &TrackedError{
+		realm: &r,
+		id:    1,
+		msg:   "abc",
+	}
+
+	b := &TrackedError{
+		realm: &IntRealm{},
+		id:    1,
+		msg:   "abc",
+	}
+
+	require.False(t, a.Is(b))
+}
+
+func Test_TrackedError_4(t *testing.T) {
+	r := IntRealm{}
+	e := r.New("abc")
+	require.Equal(t, Realm(&r), e.Realm())
+}
+
+func Test_TrackedError_5(t *testing.T) {
+	a := &TrackedError{
+		realm: &IntRealm{},
+		id:    1,
+		code:  "ABC-1",
+		msg:   "abc",
+	}
+
+	b := &TrackedError{
+		realm: &IntRealm{},
+		id:    2,
+		code:  "ABC-1",
+		msg:   "efg",
+	}
+
+	c := &TrackedError{
+		realm: a.realm,
+		id:    1,
+		code:  "ABC-1",
+		msg:   "efg",
+	}
+
+	require.False(t, a.Is(b))
+	require.True(t, a.Is(c))
+}
+
+func Test_TrackedError_6(t *testing.T) {
+	r := IntRealm{}
+	a := r.New("abc")
+	b := a.Describe("efg")
+
+	require.Same(t, a, b)
+	require.Equal(t, "efg", a.Description())
+	require.Equal(t, "efg", a.Because("hij").(*TrackedError).Description())
+}
+
+func Test_TrackedError_7(t *testing.T) {
+	r1, r2 := IntRealm{}, IntRealm{}
+
+	a := r1.Coded("DB-1", "a")
+	b := r2.Coded("DB-1", "b")
+
+	require.False(t, errors.Is(a, b))
+	require.False(t, errors.Is(b.Because("c"), a))
+	require.True(t, errors.Is(a.Because("c"), a))
+}
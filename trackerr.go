@@ -7,7 +7,6 @@ package trackerr
 
 import (
 	"errors"
-	"fmt"
 )
 
 var (
@@ -39,6 +38,9 @@ type ErrorThatWraps interface {
 }
 
 // All returns true only if errors.Is returns true for all targets.
+//
+// Like errors.Is, all branches of errors that wrap multiple causes are
+// searched.
 func All(e error, targets ...error) bool {
 	for _, t := range targets {
 		if !errors.Is(e, t) {
@@ -59,14 +61,41 @@ func All(e error, targets ...error) bool {
 //		AND first target is found first
 //		AND the second target is found second
 //		And the third target is found last
+//
+// If the error stack is a tree, such as when errors.Join is used, then true
+// is returned if the targets are found in order along any single path from
+// e to a root cause.
 func AllOrdered(e error, targets ...error) bool {
+	if len(targets) == 0 {
+		return true
+	}
+
+	for _, p := range paths(e) {
+		if allOrdered(p, targets) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func allOrdered(path []error, targets []error) bool {
+	i := 0
+
 	for _, t := range targets {
-		if !errors.Is(e, t) {
-			return false
+		last := -1
+
+		for j := i; j < len(path); j++ {
+			if IsShallow(path[j], t) {
+				last = j
+			}
 		}
 
-		for e = Unwrap(e); errors.Is(e, t); e = Unwrap(e) {
+		if last < 0 {
+			return false
 		}
+
+		i = last + 1
 	}
 
 	return true
@@ -84,26 +113,17 @@ func Any(e error, targets ...error) bool {
 }
 
 // HasTracked returns true if the error or one of the underlying causes are
-// tracked, i.e. those created via the New or Track functions.
+// tracked, i.e. those created via the New or Track functions. All branches of
+// errors that wrap multiple causes are searched.
 func HasTracked(e error) bool {
+	found := false
 
-	type wrapper interface {
-		Unwrap() error
-	}
+	WalkStack(e, func(err error, _ int) bool {
+		found = found || IsTracked(err)
+		return !found
+	})
 
-	for e != nil {
-		if IsTracked(e) {
-			return true
-		}
-
-		if w, ok := e.(wrapper); ok {
-			e = w.Unwrap()
-		} else {
-			e = nil
-		}
-	}
-
-	return false
+	return found
 }
 
 // Is is a proxy for errors.Is.
@@ -112,9 +132,9 @@ func Is(e, target error) bool {
 }
 
 // IsTracked returns true if the error is being tracked, i.e. those created via
-// the New or Track functions.
+// the New or Track functions or templates such as NewTemplate1.
 func IsTracked(e error) bool {
-	_, ok := e.(*TrackedError)
+	_, ok := asTracked(e)
 	return ok
 }
 
@@ -136,46 +156,3 @@ func IsTrackerr(e error) bool {
 func Unwrap(e error) error {
 	return errors.Unwrap(e)
 }
-
-// Debug pretty prints the error stack trace to terminal for debugging
-// purposes.
-//
-// If e is nil then a message will be printed indicating so. This function is
-// not designed for logging, just day to day manual debugging.
-func Debug(e error) (int, error) {
-	s := ErrorStack(e)
-
-	if s == "" {
-		return fmt.Print("[DEBUG ERROR] nil error")
-	}
-
-	return fmt.Print("[DEBUG ERROR]\n  ", s)
-}
-
-// DebugPanic recovers from a panic, prints out the error using the Debug
-// function, and finally sets it as the catch error's pointer value.
-//
-// If nil is passed as the catch then the panic continues after printing.
-//
-// If the panic value is not an error the panic will continue!
-//
-// This function is not designed for logging, just day to day manual debugging.
-func DebugPanic(catch *error) {
-	v := recover()
-
-	if v == nil {
-		return
-	}
-
-	e, ok := v.(error)
-	if !ok {
-		panic(v)
-	}
-
-	Debug(e)
-
-	if catch == nil {
-		panic(e)
-	}
-	*catch = e
-}
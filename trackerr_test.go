@@ -90,3 +90,30 @@ func Test_Any_1(t *testing.T) {
 	require.False(t, Any(e))
 	require.False(t, Any(e, x, y, z))
 }
+
+func Test_Errors_1(t *testing.T) {
+	errs := Errors()
+	indexOf := func(target *TrackedError) int {
+		for i, e := range errs {
+			if e == target {
+				return i
+			}
+		}
+		return -1
+	}
+
+	require.GreaterOrEqual(t, indexOf(ErrTodo), 0)
+	require.Equal(t, indexOf(ErrTodo)+1, indexOf(ErrBug))
+	require.Equal(t, indexOf(ErrBug)+1, indexOf(ErrInsane))
+
+	act, ok := Lookup(ErrBug.ID())
+	require.True(t, ok)
+	require.Same(t, ErrBug, act)
+}
+
+func Test_pkgOfFunc_1(t *testing.T) {
+	require.Equal(t, "github.com/a/b", pkgOfFunc("github.com/a/b.init"))
+	require.Equal(t, "github.com/a/b", pkgOfFunc("github.com/a/b.(*T).M"))
+	require.Equal(t, "github.com/a/b.c", pkgOfFunc("github.com/a/b%2ec.Func"))
+	require.Equal(t, "main", pkgOfFunc("main.init.0"))
+}
@@ -0,0 +1,18 @@
+// Command trackerrlint reports misuse of trackerr.
+//
+//		trackerrlint ./...
+//
+// It can also be used via go vet.
+//
+//		go vet -vettool=$(which trackerrlint) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/PaulioRandall/go-trackerr/trackerrlint"
+)
+
+func main() {
+	singlechecker.Main(trackerrlint.Analyzer)
+}
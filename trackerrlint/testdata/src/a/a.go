@@ -0,0 +1,58 @@
+package a
+
+import (
+	"errors"
+
+	"github.com/PaulioRandall/go-trackerr"
+)
+
+var (
+	ErrExported = trackerr.New("Exported")
+	errUsed     = trackerr.Coded("A-1", "Used")
+	errUnused   = trackerr.New("Unused")                    // want "tracked error errUnused is declared but never used"
+	errDescribe = trackerr.Track("Unused").Describe("Desc") // want "tracked error errDescribe is declared but never used"
+	errTemplate = trackerr.NewTemplate1[int]("User %d", "user")
+	errInit     error
+	notTracked  = errors.New("Not tracked")
+
+	_ = trackerr.New("Blank")
+)
+
+func init() {
+	errInit = trackerr.New("Init")
+}
+
+func create() error {
+	realm := &trackerr.IntRealm{}
+	_ = realm.New("Realms are fine")
+
+	return trackerr.New("Not allowed") // want "tracked errors should only be created in package level variable declarations or init functions"
+}
+
+func compare(e error) bool {
+	if e == nil || errUsed != nil {
+		return false
+	}
+
+	if errors.Is(e, errUsed) || e == notTracked {
+		return true
+	}
+
+	return e == errTemplate.With(1) || e != ErrExported // want "tracked errors should be compared using errors.Is, not ==" "tracked errors should be compared using errors.Is, not !="
+}
+
+func format(path string, args ...any) []error {
+	return []error{
+		errUsed.Because("File '%s' not found", path, trackerr.KV("path", path)),
+		errUsed.Because("%d%% of %*d", 1, 2, 3),
+		errUsed.Because("No verbs"),
+		errUsed.Because("%s %s", args...),
+		errUsed.Because("%[1]s %[1]s", path),
+		errUsed.Because("File '%s' not found"),                     /* want "Because format reads 1 args but call has 0 args, excluding Attrs" */
+		errUsed.BecauseOf(errInit, "Extra", path),                  // want "BecauseOf format reads 0 args but call has 1 args, excluding Attrs"
+		trackerr.Untracked("%s and %v", path, trackerr.KV("k", 1)), // want "Untracked format reads 2 args but call has 1 args, excluding Attrs"
+		trackerr.Untracked("%s", path).Because("%d"),               // want "Because format reads 1 args but call has 0 args, excluding Attrs"
+		trackerr.Wrap(errInit, "%s", path),
+		trackerr.Checkpoint(errInit, "%s"), // want "Checkpoint format reads 1 args but call has 0 args, excluding Attrs"
+	}
+}
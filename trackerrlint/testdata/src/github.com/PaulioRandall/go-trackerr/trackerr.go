@@ -0,0 +1,40 @@
+// Package trackerr is a stub of the real package for testing.
+package trackerr
+
+type Attr struct {
+	Key   string
+	Value any
+}
+
+func KV(key string, value any) Attr { return Attr{} }
+
+type TrackedError struct{}
+
+func New(msg string, args ...any) *TrackedError                      { return nil }
+func Track(msg string, args ...any) *TrackedError                    { return nil }
+func Coded(code, msg string, args ...any) *TrackedError              { return nil }
+func (e TrackedError) Error() string                                 { return "" }
+func (e TrackedError) Because(msg string, args ...any) error         { return nil }
+func (e TrackedError) BecauseOf(c error, msg string, a ...any) error { return nil }
+func (e *TrackedError) Describe(desc string) *TrackedError           { return e }
+
+type UntrackedError struct{}
+
+func Untracked(msg string, args ...any) *UntrackedError                { return nil }
+func (e UntrackedError) Error() string                                 { return "" }
+func (e UntrackedError) Because(msg string, args ...any) error         { return nil }
+func (e UntrackedError) BecauseOf(c error, msg string, a ...any) error { return nil }
+
+func Wrap(cause error, msg string, args ...any) error       { return nil }
+func Checkpoint(cause error, msg string, args ...any) error { return nil }
+
+type Template1[A any] struct {
+	*TrackedError
+}
+
+func NewTemplate1[A any](msg, name string) *Template1[A] { return nil }
+func (t Template1[A]) With(a A) *TrackedError            { return nil }
+
+type IntRealm struct{}
+
+func (r *IntRealm) New(msg string, args ...any) *TrackedError { return nil }
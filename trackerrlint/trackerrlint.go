@@ -0,0 +1,314 @@
+// Package trackerrlint defines an Analyzer that reports misuse of trackerr.
+//
+// It reports:
+//
+//   - tracked errors created outside package level variable declarations or
+//     init functions, which would panic at runtime once trackerr.Initialised
+//     has been called
+//   - unexported tracked errors that are declared but never used
+//   - tracked errors compared with == or != rather than errors.Is
+//   - format strings passed to Because, BecauseOf, Untracked, Wrap, or
+//     Checkpoint that don't match the number of arguments, excluding Attrs
+//
+// The Analyzer can be run via the trackerrlint command or go vet:
+//
+//		go vet -vettool=$(which trackerrlint) ./...
+package trackerrlint
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// TrackerrPkg is the import path of the trackerr package.
+const TrackerrPkg = "github.com/PaulioRandall/go-trackerr"
+
+// Analyzer reports misuse of trackerr.
+var Analyzer = &analysis.Analyzer{
+	Name:     "trackerrlint",
+	Doc:      "report misuse of trackerr tracked errors",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+// constructors are the trackerr functions that declare tracked errors.
+var constructors = map[string]bool{
+	"New":            true,
+	"Track":          true,
+	"Coded":          true,
+	"NewTemplate1":   true,
+	"NewTemplate2":   true,
+	"NewTemplate3":   true,
+	"CodedTemplate1": true,
+	"CodedTemplate2": true,
+	"CodedTemplate3": true,
+}
+
+// formatters maps the trackerr functions and methods that format messages to
+// the index of their format argument.
+var formatters = map[string]int{
+	"Because":    0,
+	"BecauseOf":  1,
+	"Untracked":  0,
+	"Wrap":       1,
+	"Checkpoint": 1,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	// trackerr creates tracked errors on behalf of others so is exempt.
+	if pass.Pkg.Path() == TrackerrPkg {
+		return nil, nil
+	}
+
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodes := []ast.Node{
+		(*ast.CallExpr)(nil),
+		(*ast.BinaryExpr)(nil),
+	}
+
+	ins.WithStack(nodes, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		switch v := n.(type) {
+		case *ast.CallExpr:
+			checkConstructor(pass, v, stack)
+			checkFormat(pass, v)
+		case *ast.BinaryExpr:
+			checkComparison(pass, v)
+		}
+
+		return true
+	})
+
+	checkUnused(pass)
+	return nil, nil
+}
+
+func trackerrFunc(pass *analysis.Pass, call *ast.CallExpr) (*types.Func, bool) {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != TrackerrPkg {
+		return nil, false
+	}
+	return fn, true
+}
+
+func isConstructor(pass *analysis.Pass, call *ast.CallExpr) bool {
+	fn, ok := trackerrFunc(pass, call)
+	if !ok {
+		return false
+	}
+
+	sig := fn.Type().(*types.Signature)
+	return sig.Recv() == nil && constructors[fn.Name()]
+}
+
+func checkConstructor(pass *analysis.Pass, call *ast.CallExpr, stack []ast.Node) {
+	if !isConstructor(pass, call) {
+		return
+	}
+
+	for _, n := range stack {
+		fd, ok := n.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if fd.Recv == nil && fd.Name.Name == "init" {
+			return
+		}
+
+		pass.Reportf(call.Pos(), "tracked errors should only be created in package level variable declarations or init functions")
+		return
+	}
+}
+
+func checkComparison(pass *analysis.Pass, expr *ast.BinaryExpr) {
+	if expr.Op != token.EQL && expr.Op != token.NEQ {
+		return
+	}
+
+	if isNil(pass, expr.X) || isNil(pass, expr.Y) {
+		return
+	}
+
+	if isTrackedType(pass.TypesInfo.TypeOf(expr.X)) || isTrackedType(pass.TypesInfo.TypeOf(expr.Y)) {
+		pass.Reportf(expr.OpPos, "tracked errors should be compared using errors.Is, not %s", expr.Op)
+	}
+}
+
+func isNil(pass *analysis.Pass, expr ast.Expr) bool {
+	tv, ok := pass.TypesInfo.Types[expr]
+	return ok && tv.IsNil()
+}
+
+// isTrackedType returns true for TrackedError, *TrackedError, and pointers to
+// tracked error templates.
+func isTrackedType(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+
+	obj := named.Obj()
+	if obj.Pkg() == nil || obj.Pkg().Path() != TrackerrPkg {
+		return false
+	}
+
+	return obj.Name() == "TrackedError" || strings.HasPrefix(obj.Name(), "Template")
+}
+
+func checkFormat(pass *analysis.Pass, call *ast.CallExpr) {
+	fn, ok := trackerrFunc(pass, call)
+	if !ok {
+		return
+	}
+
+	i, ok := formatters[fn.Name()]
+	if !ok || len(call.Args) <= i || call.Ellipsis.IsValid() {
+		return
+	}
+
+	tv, ok := pass.TypesInfo.Types[call.Args[i]]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return
+	}
+
+	want, ok := countVerbs(constant.StringVal(tv.Value))
+	if !ok {
+		return
+	}
+
+	have := 0
+	for _, a := range call.Args[i+1:] {
+		if !isAttr(pass.TypesInfo.TypeOf(a)) {
+			have++
+		}
+	}
+
+	if want != have {
+		pass.Reportf(call.Pos(), "%s format reads %d args but call has %d args, excluding Attrs", fn.Name(), want, have)
+	}
+}
+
+func isAttr(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == TrackerrPkg && obj.Name() == "Attr"
+}
+
+// countVerbs returns the number of arguments read by the format string. False
+// is returned if the format uses explicit argument indexes as the count can't
+// easily be determined.
+func countVerbs(format string) (int, bool) {
+	n := 0
+
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+
+		for i++; i < len(format); i++ {
+			c := format[i]
+
+			switch {
+			case c == '[':
+				return 0, false
+			case c == '*':
+				n++
+			case strings.IndexByte("+-# 0.", c) >= 0, c >= '0' && c <= '9':
+			case c == '%':
+				goto next
+			default:
+				n++
+				goto next
+			}
+		}
+	next:
+	}
+
+	return n, true
+}
+
+// checkUnused reports unexported package level tracked errors that are never
+// referenced within the package.
+func checkUnused(pass *analysis.Pass) {
+	used := map[types.Object]bool{}
+	for _, obj := range pass.TypesInfo.Uses {
+		used[obj] = true
+	}
+
+	for _, f := range pass.Files {
+		for _, d := range f.Decls {
+			gd, ok := d.(*ast.GenDecl)
+			if !ok || gd.Tok != token.VAR {
+				continue
+			}
+
+			for _, s := range gd.Specs {
+				checkUnusedSpec(pass, s.(*ast.ValueSpec), used)
+			}
+		}
+	}
+}
+
+func checkUnusedSpec(pass *analysis.Pass, vs *ast.ValueSpec, used map[types.Object]bool) {
+	for i, v := range vs.Values {
+		if i >= len(vs.Names) {
+			return
+		}
+
+		id := vs.Names[i]
+		if id.Name == "_" || id.IsExported() || !isDeclaration(pass, v) {
+			continue
+		}
+
+		if obj := pass.TypesInfo.Defs[id]; obj != nil && !used[obj] {
+			pass.Reportf(id.Pos(), "tracked error %s is declared but never used", id.Name)
+		}
+	}
+}
+
+// isDeclaration returns true if the expression is a call to a tracked error
+// constructor, possibly followed by chained calls such as Describe.
+func isDeclaration(pass *analysis.Pass, expr ast.Expr) bool {
+	for {
+		call, ok := expr.(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+
+		if isConstructor(pass, call) {
+			return true
+		}
+
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return false
+		}
+
+		expr = sel.X
+	}
+}
@@ -0,0 +1,25 @@
+package trackerrlint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func Test_Analyzer_1(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "a")
+}
+
+func Test_countVerbs_1(t *testing.T) {
+	n, ok := countVerbs("%s %-5d %+v %#x %.2f %%")
+	require.True(t, ok)
+	require.Equal(t, 5, n)
+
+	n, ok = countVerbs("%*d %")
+	require.True(t, ok)
+	require.Equal(t, 2, n)
+
+	_, ok = countVerbs("%[2]s %[1]s")
+	require.False(t, ok)
+}
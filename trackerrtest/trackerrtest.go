@@ -0,0 +1,146 @@
+// Package trackerrtest provides test assertions for trackerr errors.
+//
+// On failure, each assertion reports the expected errors, by code and
+// message, alongside the full error stack of the actual error so there's no
+// need to go digging with a debugger.
+//
+//		func TestLoad(t *testing.T) {
+//			_, e := Load("missing.csv")
+//			trackerrtest.RequireAllOrdered(t, e, ErrLoading, ErrFileNotFound)
+//		}
+//
+//		```
+//		Expected error stack to contain, in order:
+//		  Failed to load
+//		  [FS-0001] File not found
+//		Actual error stack:
+//		  Failed to load
+//		  ⤷ Permission denied
+//		```
+package trackerrtest
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/PaulioRandall/go-trackerr"
+)
+
+// RequireIs fails the test immediately unless errors.Is returns true for the
+// error and target.
+func RequireIs(t testing.TB, e, target error) {
+	t.Helper()
+
+	if !errors.Is(e, target) {
+		t.Fatal(report("Expected error stack to contain:", e, target))
+	}
+}
+
+// RequireAllOrdered fails the test immediately unless trackerr.AllOrdered
+// returns true for the error and targets.
+func RequireAllOrdered(t testing.TB, e error, targets ...error) {
+	t.Helper()
+
+	if !trackerr.AllOrdered(e, targets...) {
+		t.Fatal(report("Expected error stack to contain, in order:", e, targets...))
+	}
+}
+
+// RequireNotTracked fails the test immediately if any of the targets are
+// within the error stack. If no targets are passed then the test fails if the
+// error stack contains any tracked errors at all.
+//
+//		trackerrtest.RequireNotTracked(t, e, ErrInternal)
+func RequireNotTracked(t testing.TB, e error, targets ...error) {
+	t.Helper()
+
+	if len(targets) == 0 {
+		if trackerr.HasTracked(e) {
+			t.Fatal(report("Expected error stack to contain no tracked errors", e))
+		}
+		return
+	}
+
+	for _, target := range targets {
+		if errors.Is(e, target) {
+			t.Fatal(report("Expected error stack not to contain:", e, target))
+			return
+		}
+	}
+}
+
+// RequireStackShape fails the test immediately unless each error in the stack,
+// as returned by trackerr.SliceStack, matches the corresponding item in shape.
+// A nil item matches any error that isn't tracked.
+//
+//		trackerrtest.RequireStackShape(t, e,
+//			ErrLoading,
+//			nil, // Any untracked error
+//			ErrFileNotFound,
+//		)
+func RequireStackShape(t testing.TB, e error, shape ...error) {
+	t.Helper()
+
+	msg := "Expected error stack with shape:"
+	stack := trackerr.SliceStack(e)
+
+	if len(stack) != len(shape) {
+		t.Fatal(report(msg, e, shape...))
+		return
+	}
+
+	for i, s := range shape {
+		if !matches(stack[i], s) {
+			t.Fatal(report(msg, e, shape...))
+			return
+		}
+	}
+}
+
+func matches(e, target error) bool {
+	if target == nil {
+		return !trackerr.IsTracked(e)
+	}
+
+	return trackerr.IsShallow(e, target)
+}
+
+func report(msg string, actual error, expected ...error) string {
+	sb := strings.Builder{}
+	sb.WriteString(msg)
+	sb.WriteRune('\n')
+
+	for _, e := range expected {
+		sb.WriteString("  ")
+		sb.WriteString(describe(e))
+		sb.WriteRune('\n')
+	}
+
+	sb.WriteString("Actual error stack:\n")
+
+	if actual == nil {
+		sb.WriteString("  <nil>\n")
+		return sb.String()
+	}
+
+	s := trackerr.ErrorStackf(actual, trackerr.FormatCode(trackerr.DefaultFormatter))
+	s = strings.TrimSuffix(s, "\n")
+
+	sb.WriteString("  ")
+	sb.WriteString(strings.ReplaceAll(s, "\n", "\n  "))
+	sb.WriteRune('\n')
+
+	return sb.String()
+}
+
+func describe(e error) string {
+	if e == nil {
+		return "<any untracked error>"
+	}
+
+	if te, ok := e.(interface{ Code() string }); ok && te.Code() != "" {
+		return "[" + te.Code() + "] " + e.Error()
+	}
+	return e.Error()
+}
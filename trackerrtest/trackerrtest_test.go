@@ -0,0 +1,145 @@
+package trackerrtest
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulioRandall/go-trackerr"
+)
+
+type fakeT struct {
+	testing.TB
+	failed bool
+	msg    string
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Fatal(args ...any) {
+	t.failed = true
+	t.msg = args[0].(string)
+}
+
+var (
+	r         = &trackerr.IntRealm{}
+	errA      = r.Coded("TEST-A", "a")
+	errB      = r.New("b")
+	errUnused = r.New("unused")
+)
+
+func Test_RequireIs_1(t *testing.T) {
+	ft := &fakeT{}
+	RequireIs(ft, errA.Because("c"), errA)
+	require.False(t, ft.failed)
+
+	ft = &fakeT{}
+	RequireIs(ft, errB.Because("c"), errA)
+	require.True(t, ft.failed)
+
+	exp := "Expected error stack to contain:\n" +
+		"  [TEST-A] a\n" +
+		"Actual error stack:\n" +
+		"  b\n" +
+		"  ⤷ c\n"
+
+	require.Equal(t, exp, ft.msg)
+}
+
+func Test_RequireAllOrdered_1(t *testing.T) {
+	e := errA.CausedBy(errB)
+
+	ft := &fakeT{}
+	RequireAllOrdered(ft, e, errA, errB)
+	require.False(t, ft.failed)
+
+	ft = &fakeT{}
+	RequireAllOrdered(ft, e, errB, errA)
+	require.True(t, ft.failed)
+
+	exp := "Expected error stack to contain, in order:\n" +
+		"  b\n" +
+		"  [TEST-A] a\n" +
+		"Actual error stack:\n" +
+		"  [TEST-A] a\n" +
+		"  ⤷ b\n"
+
+	require.Equal(t, exp, ft.msg)
+}
+
+func Test_RequireNotTracked_1(t *testing.T) {
+	ft := &fakeT{}
+	RequireNotTracked(ft, trackerr.Wrap(errors.New("b"), "a"))
+	require.False(t, ft.failed)
+
+	ft = &fakeT{}
+	RequireNotTracked(ft, errA.Because("b"), errUnused)
+	require.False(t, ft.failed)
+
+	ft = &fakeT{}
+	RequireNotTracked(ft, trackerr.Wrap(errB, "a"))
+	require.True(t, ft.failed)
+
+	ft = &fakeT{}
+	RequireNotTracked(ft, errA.Because("b"), errUnused, errA)
+	require.True(t, ft.failed)
+	require.Contains(t, ft.msg, "Expected error stack not to contain:\n  [TEST-A] a\n")
+}
+
+func Test_RequireStackShape_1(t *testing.T) {
+	e := errA.BecauseOf(errB, "c")
+
+	ft := &fakeT{}
+	RequireStackShape(ft, e, errA, nil, errB)
+	require.False(t, ft.failed)
+
+	ft = &fakeT{}
+	RequireStackShape(ft, e, errA, errB)
+	require.True(t, ft.failed)
+
+	ft = &fakeT{}
+	RequireStackShape(ft, e, errA, errB, nil)
+	require.True(t, ft.failed)
+
+	exp := "Expected error stack with shape:\n" +
+		"  [TEST-A] a\n" +
+		"  b\n" +
+		"  <any untracked error>\n" +
+		"Actual error stack:\n" +
+		"  [TEST-A] a\n" +
+		"  ⤷ c\n" +
+		"  ⤷ b\n"
+
+	require.Equal(t, exp, ft.msg)
+}
+
+func Test_RequireStackShape_2(t *testing.T) {
+	ft := &fakeT{}
+	RequireStackShape(ft, nil, errA)
+	require.True(t, ft.failed)
+	require.Contains(t, ft.msg, "Actual error stack:\n  <nil>\n")
+
+	ft = &fakeT{}
+	RequireStackShape(ft, nil)
+	require.False(t, ft.failed)
+}
+
+type sliceErr []string
+
+func (e sliceErr) Error() string {
+	return strings.Join(e, ",")
+}
+
+func Test_RequireStackShape_3(t *testing.T) {
+	e := errA.CausedBy(sliceErr{"x"})
+
+	ft := &fakeT{}
+	RequireStackShape(ft, e, errA, sliceErr{"x"})
+	require.True(t, ft.failed)
+
+	ft = &fakeT{}
+	RequireStackShape(ft, e, errA, nil)
+	require.False(t, ft.failed)
+}
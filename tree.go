@@ -0,0 +1,201 @@
+package trackerr
+
+import (
+	"reflect"
+	"strings"
+)
+
+// StackNode is a node within the tree returned by TreeStack.
+type StackNode struct {
+	Err    error
+	Causes []*StackNode
+}
+
+// TreeStack recursively unwraps the error returning it as the root of a tree.
+//
+// Unlike SliceStack, the structure of errors that wrap multiple causes, such as
+// those created by errors.Join or fmt.Errorf with multiple %w verbs, is kept.
+//
+//		a := trackerr.Untracked("a")
+//		b := trackerr.Untracked("b")
+//		c := trackerr.Untracked("c")
+//
+//		root := TreeStack(a.CausedBy(errors.Join(b, c)))
+//
+//		// root: {
+//		// 	Err: a,
+//		// 	Causes: [{
+//		// 		Err: joined,
+//		// 		Causes: [
+//		// 			{ Err: b },
+//		// 			{ Err: c },
+//		// 		],
+//		// 	}],
+//		// }
+func TreeStack(e error) *StackNode {
+	if e == nil {
+		return nil
+	}
+
+	n := &StackNode{Err: e}
+	for _, c := range causes(e) {
+		n.Causes = append(n.Causes, TreeStack(c))
+	}
+
+	return n
+}
+
+// WalkStack visits the error and every error it wraps, depth first, calling f
+// for each. Errors are visited before their causes and causes are visited in
+// the order they are wrapped. Depth is the number of errors between the
+// visited error and e.
+//
+// If f returns false the causes of the visited error are skipped.
+func WalkStack(e error, f func(e error, depth int) bool) {
+	walkStack(e, 0, f)
+}
+
+func walkStack(e error, depth int, f func(e error, depth int) bool) {
+	if e == nil || !f(e, depth) {
+		return
+	}
+
+	for _, c := range causes(e) {
+		walkStack(c, depth+1, f)
+	}
+}
+
+// causes returns the non-nil errors directly wrapped by e.
+func causes(e error) []error {
+	switch v := e.(type) {
+	case interface{ Unwrap() []error }:
+		var cs []error
+		for _, c := range v.Unwrap() {
+			if c != nil {
+				cs = append(cs, c)
+			}
+		}
+		return cs
+
+	case interface{ Unwrap() error }:
+		if c := v.Unwrap(); c != nil {
+			return []error{c}
+		}
+	}
+
+	return nil
+}
+
+// paths returns every path from e to the root causes of its tree.
+func paths(e error) [][]error {
+	if e == nil {
+		return nil
+	}
+
+	cs := causes(e)
+	if len(cs) == 0 {
+		return [][]error{{e}}
+	}
+
+	var result [][]error
+	for _, c := range cs {
+		for _, p := range paths(c) {
+			result = append(result, append([]error{e}, p...))
+		}
+	}
+
+	return result
+}
+
+// isJoin returns true if the error only joins its causes together, i.e. it's a
+// MultiError or its message is its causes' messages separated by linefeeds.
+func isJoin(e error, cs []error) bool {
+	if _, ok := e.(*MultiError); ok {
+		return true
+	}
+
+	if len(cs) < 2 {
+		return false
+	}
+
+	msgs := make([]string, len(cs))
+	for i, c := range cs {
+		msgs[i] = c.Error()
+	}
+
+	return e.Error() == strings.Join(msgs, "\n")
+}
+
+// IsShallow returns true if the error e, ignoring its causes, is the target.
+// That is, e equals target or e has an Is method that returns true for it.
+//
+// Unlike errors.Is the causes of e are never unwrapped and inspected.
+func IsShallow(e, target error) bool {
+	if target == nil || e == nil {
+		return e == target
+	}
+
+	if reflect.TypeOf(target).Comparable() && e == target {
+		return true
+	}
+
+	x, ok := e.(interface{ Is(error) bool })
+	return ok && x.Is(target)
+}
+
+// stackWriter writes error trees, as returned by TreeStack, as text with
+// branches drawn using box drawing characters.
+//
+//		Validation failed
+//		├ Name is required
+//		└ Age is invalid
+//		  ⤷ Must be positive
+type stackWriter struct {
+	sb strings.Builder
+	f  ErrorFormatter
+}
+
+func (w *stackWriter) write(e error, first, rest string, isFirst bool) {
+	for e != nil {
+		cs := causes(e)
+
+		if !isJoin(e, cs) {
+			w.line(e, first, rest, isFirst)
+			first, isFirst = rest, false
+		}
+
+		if len(cs) == 0 {
+			return
+		}
+
+		if _, ok := e.(*MultiError); !ok && len(cs) == 1 {
+			e = cs[0]
+			continue
+		}
+
+		for i, c := range cs {
+			if i == len(cs)-1 {
+				w.write(c, rest+"└ ", rest+"  ", isFirst)
+			} else {
+				w.write(c, rest+"├ ", rest+"│ ", isFirst)
+			}
+		}
+		return
+	}
+}
+
+func (w *stackWriter) line(e error, first, rest string, isFirst bool) {
+	errMsg := e.Error()
+
+	if w.f != nil {
+		errMsg = w.f(errMsg, e, isFirst)
+	}
+
+	if rest != "" {
+		errMsg = strings.ReplaceAll(errMsg, "\n", "\n"+rest)
+	}
+
+	w.sb.WriteString(first)
+	w.sb.WriteString(errMsg)
+	w.sb.WriteRune('\n')
+}
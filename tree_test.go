@@ -0,0 +1,186 @@
+package trackerr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TreeStack_1(t *testing.T) {
+	b := Untracked("b")
+	d := Untracked("d")
+	c := Untracked("c").CausedBy(d)
+	join := errors.Join(b, c)
+	a := Untracked("a").CausedBy(join)
+
+	act := TreeStack(a)
+	exp := &StackNode{
+		Err: a,
+		Causes: []*StackNode{{
+			Err: join,
+			Causes: []*StackNode{
+				{Err: b},
+				{
+					Err:    c,
+					Causes: []*StackNode{{Err: d}},
+				},
+			},
+		}},
+	}
+
+	require.Equal(t, exp, act)
+	require.Nil(t, TreeStack(nil))
+}
+
+func Test_WalkStack_1(t *testing.T) {
+	b := Untracked("b")
+	c := Untracked("c")
+	d := Untracked("d")
+	e := Untracked("a").CausedBy(errors.Join(b.CausedBy(d), c))
+
+	var act []string
+	WalkStack(e, func(err error, depth int) bool {
+		act = append(act, fmt.Sprintf("%d:%s", depth, strings.ReplaceAll(err.Error(), "\n", ",")))
+		return true
+	})
+
+	exp := []string{"0:a", "1:b,c", "2:b", "3:d", "2:c"}
+	require.Equal(t, exp, act)
+
+	act = nil
+	WalkStack(e, func(err error, depth int) bool {
+		act = append(act, err.Error())
+		return err.Error() != "b"
+	})
+
+	exp = []string{"a", "b\nc", "b", "c"}
+	require.Equal(t, exp, act)
+}
+
+func Test_SliceStack_2(t *testing.T) {
+	b := Untracked("b")
+	c := Untracked("c")
+	join := errors.Join(b, c)
+	a := Untracked("a").CausedBy(join)
+
+	act := SliceStack(a)
+	exp := []error{a, join, b, c}
+
+	require.Equal(t, exp, act)
+}
+
+func Test_ErrorStack_Tree_1(t *testing.T) {
+	r := IntRealm{}
+
+	e := r.New("Validation failed").CausedBy(errors.Join(
+		Untracked("Name is required"),
+		Untracked("Age is invalid").CausedBy(errors.Join(
+			Untracked("Must be positive"),
+			Untracked("Must be an integer").Because("Got 1.5"),
+		)),
+		Untracked("Email is invalid"),
+	))
+
+	expLines := []string{
+		"Validation failed",
+		"├ ⤷ Name is required",
+		"├ ⤷ Age is invalid",
+		"│ ├ ⤷ Must be positive",
+		"│ └ ⤷ Must be an integer",
+		"│   ⤷ Got 1.5",
+		"└ ⤷ Email is invalid",
+		"",
+	}
+	exp := strings.Join(expLines, "\n")
+
+	require.Equal(t, exp, ErrorStack(e))
+}
+
+func Test_ErrorStack_Tree_2(t *testing.T) {
+	e := errors.Join(Untracked("a"), Untracked("b").Because("c"))
+
+	expLines := []string{
+		"├ a",
+		"└ b",
+		"  ⤷ c",
+		"",
+	}
+	exp := strings.Join(expLines, "\n")
+
+	require.Equal(t, exp, ErrorStack(e))
+}
+
+func Test_ErrorStack_Tree_3(t *testing.T) {
+	a := Untracked("a")
+	b := Untracked("b")
+	e := fmt.Errorf("x: %w, %w", a, b)
+
+	expLines := []string{
+		"x: a, b",
+		"├ ⤷ a",
+		"└ ⤷ b",
+		"",
+	}
+	exp := strings.Join(expLines, "\n")
+
+	require.Equal(t, exp, ErrorStack(e))
+}
+
+func Test_HasTracked_Tree_1(t *testing.T) {
+	a := Untracked("a")
+	b := Untracked("b")
+	c := New("c")
+
+	require.True(t, HasTracked(a.CausedBy(errors.Join(b, c))))
+	require.False(t, HasTracked(a.CausedBy(errors.Join(b, b))))
+}
+
+func Test_All_Tree_1(t *testing.T) {
+	a := New("a")
+	b := New("b")
+	c := New("c")
+	d := New("d")
+
+	e := a.CausedBy(errors.Join(b, c))
+
+	require.True(t, All(e, a, b, c))
+	require.False(t, All(e, a, d))
+}
+
+func Test_AllOrdered_Tree_1(t *testing.T) {
+	a := New("a")
+	b := New("b")
+	c := New("c")
+	d := New("d")
+
+	e := a.CausedBy(errors.Join(b.CausedBy(d), c))
+
+	require.True(t, AllOrdered(e, a, b, d))
+	require.True(t, AllOrdered(e, a, c))
+	require.True(t, AllOrdered(e, b, d))
+	require.False(t, AllOrdered(e, d, b))
+	require.False(t, AllOrdered(e, b, c))
+	require.False(t, AllOrdered(e, c, d))
+}
+
+type sliceErr []string
+
+func (e sliceErr) Error() string {
+	return strings.Join(e, ",")
+}
+
+func Test_IsShallow_1(t *testing.T) {
+	r := IntRealm{}
+	a := r.New("a")
+	e := fmt.Errorf("w: %w", sliceErr{"x"})
+
+	require.True(t, IsShallow(a, a))
+	require.True(t, IsShallow(a.Because("b"), a))
+	require.False(t, IsShallow(a.CausedBy(errors.New("b")), r.New("b")))
+	require.False(t, IsShallow(sliceErr{"x"}, sliceErr{"x"}))
+	require.False(t, AllOrdered(e, sliceErr{"x"}))
+	require.True(t, AllOrdered(a.CausedBy(e), a))
+}
@@ -2,8 +2,12 @@ package trackerr
 
 // UntrackedError represents an untracked error in an error stack.
 type UntrackedError struct {
-	msg   string
-	cause error
+	msg        string
+	cause      error
+	attrs      []Attr
+	pcs        []uintptr
+	checkpoint bool
+	public     string
 }
 
 // Untracked returns a new error without a tracking ID.
@@ -11,8 +15,15 @@ type UntrackedError struct {
 // This is the same as calling errors.New except for the handy fmt.Sprintf
 // function signature and the resultant error has a few extra receiving
 // functions for attaching causal errors.
+//
+// Any Attr values within args are removed before formatting and attached to
+// the error instead.
+//
+//		e := trackerr.Untracked("File not found", trackerr.KV("path", path))
 func Untracked(msg string, args ...any) *UntrackedError {
-	return because(msg, args...)
+	e := because(msg, args...)
+	e.pcs = capture(nil, 1)
+	return e
 }
 
 // Because constructs a cause from msg and args.
@@ -25,8 +36,13 @@ func Untracked(msg string, args ...any) *UntrackedError {
 //		wrapper message
 //		⤷ cause message
 //		```
+//
+// Any Attr values within args are removed before formatting and attached to
+// the new cause instead.
 func (e UntrackedError) Because(msg string, args ...any) error {
-	return e.BecauseOf(nil, msg, args...)
+	e.pcs = capture(nil, 1)
+	e.cause = because(msg, args...)
+	return &e
 }
 
 // BecauseOf creates a new error using the msg, args, and cause as arguments
@@ -46,7 +62,8 @@ func (e UntrackedError) Because(msg string, args ...any) error {
 //		⤷ root cause message
 //		```
 func (e UntrackedError) BecauseOf(rootCause error, msg string, args ...any) error {
-	e.cause = Untracked(msg, args...).CausedBy(rootCause)
+	e.pcs = capture(nil, 1)
+	e.cause = causedBy(rootCause, msg, args...)
 	return &e
 }
 
@@ -80,11 +97,41 @@ func (e UntrackedError) BecauseOf(rootCause error, msg string, args ...any) erro
 //		⤷ cause message
 //		```
 func (e UntrackedError) CausedBy(rootCause error, causes ...ErrorThatWraps) error {
-	c := Stack(rootCause, causes...)
-	e.cause = c
+	e.pcs = capture(nil, 1)
+	e.cause = stack(1, rootCause, causes...)
+	return &e
+}
+
+// CallSite returns the location where the error was created or wrapped.
+//
+// Call sites are only recorded when capturing is enabled, see SetCapture.
+func (e UntrackedError) CallSite() (Frame, bool) {
+	return callSite(e.pcs)
+}
+
+// WithAttrs returns a copy of the error with the attributes appended to its
+// own. This is useful for attaching attributes before calling CausedBy.
+//
+//		e := ErrLoadingData.WithAttrs(trackerr.KV("retries", 3)).CausedBy(cause)
+func (e UntrackedError) WithAttrs(attrs ...Attr) *UntrackedError {
+	e.attrs = appendAttrs(e.attrs, attrs)
 	return &e
 }
 
+// Attrs returns the error's own attributes, not those of its causes. See
+// CollectAttrs for gathering attributes from the whole stack.
+func (e UntrackedError) Attrs() []Attr {
+	return e.attrs
+}
+
+// StackTrace returns the call stack at the point the error was created or
+// wrapped. If only the call site was recorded then it will be the only Frame.
+//
+// Stacks are only recorded when capturing is enabled, see SetCapture.
+func (e UntrackedError) StackTrace() []Frame {
+	return stackTrace(e.pcs)
+}
+
 // Error satisfies the error interface.
 func (e UntrackedError) Error() string {
 	return e.msg